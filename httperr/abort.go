@@ -0,0 +1,34 @@
+package httperr
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Option modifies error response
+type Option func(c *gin.Context)
+
+// WithHeader sets response header
+func WithHeader(name string, value string) Option {
+	return func(c *gin.Context) {
+		c.Header(name, value)
+	}
+}
+
+// WithRetryAfter sets Retry-After header in seconds, rounded up
+func WithRetryAfter(d time.Duration) Option {
+	return WithHeader("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+}
+
+// Abort applies options, responds with error and aborts the handlers chain
+func Abort(c *gin.Context, err *Error, opts ...Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	Respond(c, err)
+	c.Abort()
+}
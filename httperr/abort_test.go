@@ -0,0 +1,58 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const abortTestURL = "/abort"
+
+func TestAbort(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	called := false
+
+	router.GET(abortTestURL, func(c *gin.Context) {
+		Abort(c, NewError(http.StatusServiceUnavailable, "maintenance"), WithHeader("X-Maintenance", "true"), WithRetryAfter(1500*time.Millisecond))
+	}, func(c *gin.Context) {
+		called = true
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, abortTestURL, nil))
+
+	assert.False(called)
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+	assert.Equal("true", w.Header().Get("X-Maintenance"))
+	assert.Equal("2", w.Header().Get("Retry-After"))
+
+	err := new(Error)
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), err))
+	assert.Equal(http.StatusServiceUnavailable, err.Status)
+	assert.Equal("maintenance", err.Message)
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	for d, expected := range map[time.Duration]string{
+		0:                             "0",
+		time.Millisecond:              "1",
+		time.Second:                   "1",
+		time.Minute + time.Nanosecond: "61",
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		WithRetryAfter(d)(c)
+		assert.Equal(expected, w.Header().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,146 @@
+package httperr
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogEntry application error code with default status, message template and documentation link
+// Message can reference parameters as {name}
+type CatalogEntry struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	DocURL  string `json:"doc_url,omitempty"`
+}
+
+// Params error message parameters
+type Params map[string]interface{}
+
+// NewCatalog creates empty error code catalog
+func NewCatalog() *Catalog {
+	return &Catalog{
+		entries: map[string]*CatalogEntry{},
+	}
+}
+
+// Catalog registry of application error codes, safe for concurrent use
+type Catalog struct {
+	mut     sync.RWMutex
+	entries map[string]*CatalogEntry
+}
+
+// DefaultCatalog catalog used by Register and RespondCode
+var DefaultCatalog = NewCatalog()
+
+// Register adds entries to the catalog, codes should be unique and statuses should be 4xx or 5xx
+// nothing is registered if any of the entries is invalid
+func (ct *Catalog) Register(entries ...CatalogEntry) error {
+	ct.mut.Lock()
+	defer ct.mut.Unlock()
+
+	codes := map[string]bool{}
+
+	for _, entry := range entries {
+		if len(entry.Code) <= 0 {
+			return fmt.Errorf("error code is required")
+		}
+
+		if _, ok := ct.entries[entry.Code]; ok || codes[entry.Code] {
+			return fmt.Errorf("error code %s is already registered", entry.Code)
+		}
+
+		codes[entry.Code] = true
+
+		if entry.Status < http.StatusBadRequest || len(http.StatusText(entry.Status)) <= 0 {
+			return fmt.Errorf("error code %s has invalid status %d", entry.Code, entry.Status)
+		}
+	}
+
+	for _, entry := range entries {
+		entry := entry
+		ct.entries[entry.Code] = &entry
+	}
+
+	return nil
+}
+
+// Entry get catalog entry by code
+func (ct *Catalog) Entry(code string) (CatalogEntry, bool) {
+	ct.mut.RLock()
+	defer ct.mut.RUnlock()
+
+	entry, ok := ct.entries[code]
+
+	if !ok {
+		return CatalogEntry{}, false
+	}
+
+	return *entry, true
+}
+
+// Entries all catalog entries sorted by code
+func (ct *Catalog) Entries() []CatalogEntry {
+	ct.mut.RLock()
+	defer ct.mut.RUnlock()
+
+	entries := make([]CatalogEntry, 0, len(ct.entries))
+
+	for _, entry := range ct.entries {
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+
+	return entries
+}
+
+// Error creates error for the code with the message template filled with params,
+// unknown codes result in internal server error
+func (ct *Catalog) Error(code string, params Params) *Error {
+	entry, ok := ct.Entry(code)
+
+	if !ok {
+		return NewError(http.StatusInternalServerError, fmt.Sprintf("unknown error code %s", code))
+	}
+
+	err := NewError(entry.Status, fillParams(entry.Message, params))
+	err.Code = entry.Code
+	err.Params = params
+	err.Type = entry.DocURL
+
+	return err
+}
+
+func fillParams(template string, params Params) string {
+	replacements := make([]string, 0, len(params)*2)
+
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// Register adds entries to the DefaultCatalog
+func Register(entries ...CatalogEntry) error {
+	return DefaultCatalog.Register(entries...)
+}
+
+// RespondCode responds with DefaultCatalog error code
+func RespondCode(c *gin.Context, code string, params ...Params) {
+	var p Params
+
+	if len(params) > 0 {
+		p = params[0]
+	}
+
+	Respond(c, DefaultCatalog.Error(code, p))
+}
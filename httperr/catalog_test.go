@@ -0,0 +1,89 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog(t *testing.T) {
+	assert := assert.New(t)
+
+	catalog := NewCatalog()
+	assert.NoError(catalog.Register(CatalogEntry{
+		Code:    "USER_NOT_FOUND",
+		Status:  http.StatusNotFound,
+		Message: "user {id} not found",
+		DocURL:  "https://example.com/errors/user-not-found",
+	}))
+	assert.Error(catalog.Register(CatalogEntry{Code: "USER_NOT_FOUND", Status: http.StatusNotFound}))
+	assert.Error(catalog.Register(CatalogEntry{Status: http.StatusNotFound}))
+	assert.Error(catalog.Register(CatalogEntry{Code: "INVALID", Status: 999}))
+	assert.Error(catalog.Register(CatalogEntry{Code: "OK", Status: http.StatusOK}))
+	assert.Error(catalog.Register(
+		CatalogEntry{Code: "DUPLICATE", Status: http.StatusConflict},
+		CatalogEntry{Code: "DUPLICATE", Status: http.StatusGone},
+	))
+
+	_, ok := catalog.Entry("DUPLICATE")
+	assert.False(ok)
+	assert.Len(catalog.Entries(), 1)
+
+	entry, ok := catalog.Entry("USER_NOT_FOUND")
+	assert.True(ok)
+	assert.Equal(http.StatusNotFound, entry.Status)
+
+	err := catalog.Error("USER_NOT_FOUND", Params{"id": 42})
+	assert.Equal(http.StatusNotFound, err.Status)
+	assert.Equal("user 42 not found", err.Message)
+	assert.Equal("USER_NOT_FOUND", err.Code)
+	assert.Equal(Params{"id": 42}, err.Params)
+	assert.Equal("https://example.com/errors/user-not-found", err.Type)
+
+	err = catalog.Error("UNKNOWN", nil)
+	assert.Equal(http.StatusInternalServerError, err.Status)
+}
+
+func TestRespondCode(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	defaultCatalog := DefaultCatalog
+	DefaultCatalog = NewCatalog()
+	defer func() { DefaultCatalog = defaultCatalog }()
+
+	assert.NoError(Register(CatalogEntry{Code: "USER_NOT_FOUND", Status: http.StatusNotFound, Message: "user {id} not found"}))
+
+	router := gin.New()
+	router.GET("/users/:id", func(c *gin.Context) {
+		RespondCode(c, "USER_NOT_FOUND", Params{"id": c.Param("id")})
+	})
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	body := map[string]interface{}{}
+	assert.NoError(json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Equal(map[string]interface{}{
+		"status":  404.0,
+		"message": "user 1 not found",
+		"code":    "USER_NOT_FOUND",
+		"params":  map[string]interface{}{"id": "1"},
+	}, body)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/2", nil)
+	req.Header.Set("Accept", ProblemContentType)
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	body = map[string]interface{}{}
+	assert.NoError(json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Equal("USER_NOT_FOUND", body["code"])
+	assert.Equal("user 2 not found", body["detail"])
+	assert.Equal("about:blank", body["type"])
+}
@@ -1,98 +1,191 @@
 package httperr
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Error http error struct
+// Code and Params are set for catalog errors, see Catalog, Fields for request validation errors, see Validation
+// RequestID and TraceID are set by Respond from the request, see RequestID and TraceID
+// Type, Title, Detail, Instance and Extensions are used by problem details responses (RFC 7807)
 type Error struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
+	Type       string                 `json:"type,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Status     int                    `json:"status"`
+	Message    string                 `json:"message"`
+	Code       string                 `json:"code,omitempty"`
+	Params     Params                 `json:"params,omitempty"`
+	Fields     []FieldError           `json:"errors,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	TraceID    string                 `json:"trace_id,omitempty"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+	cause      error
 }
 
 // NewError create http response error
 func NewError(status int, message string) *Error {
 	return &Error{
-		status,
-		message,
+		Status:  status,
+		Message: message,
 	}
 }
 
-// NotFound http not found error
-func NotFound(c *gin.Context, error ...string) {
-	err := NewError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+// Wrap creates error caused by another error, message defaults to the cause message for 4xx statuses
+// and to status text for 5xx ones so internal details are not exposed
+func Wrap(status int, cause error, message ...string) *Error {
+	err := NewError(status, http.StatusText(status))
+	err.cause = cause
 
-	if len(error) > 0 {
-		err.Message = error[0]
+	if len(message) > 0 {
+		err.Message = message[0]
+	} else if cause != nil && status < http.StatusInternalServerError {
+		err.Message = cause.Error()
 	}
 
-	c.JSON(err.Status, err)
+	return err
 }
 
-// InternalServerError http internal server error
-func InternalServerError(c *gin.Context, error ...string) {
-	err := NewError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+// Wrapf creates error caused by another error with formatted message
+func Wrapf(status int, cause error, format string, args ...interface{}) *Error {
+	return Wrap(status, cause, fmt.Sprintf(format, args...))
+}
 
-	if len(error) > 0 {
-		err.Message = error[0]
+// Error message of the error, includes the cause if any and it differs from the message
+func (e *Error) Error() string {
+	if e.cause != nil && e.cause.Error() != e.Message {
+		return fmt.Sprintf("%s: %s", e.Message, e.cause)
 	}
 
-	c.JSON(err.Status, err)
+	return e.Message
 }
 
-// UnprocessableEntity http unprocessable entity
-func UnprocessableEntity(c *gin.Context, error ...string) {
-	err := NewError(http.StatusUnprocessableEntity, http.StatusText(http.StatusUnprocessableEntity))
+// Unwrap returns the cause of the error
+func (e *Error) Unwrap() error {
+	return e.cause
+}
 
-	if len(error) > 0 {
-		err.Message = error[0]
-	}
+// StatusCode http status of the error
+func (e *Error) StatusCode() int {
+	return e.Status
+}
 
-	c.JSON(err.Status, err)
+// Respond writes error response in the format negotiated with Accept header (JSON by default),
+// problem details document is used for all requests when enabled with ProblemDetails
+// 5xx errors are sent to the reporter, see SetReporter, messages are localized, see SetLocalizer
+// request and trace IDs are added to the error and echoed in X-Request-ID and X-Trace-ID headers
+func Respond(c *gin.Context, err *Error) {
+	report(c, err)
+	negotiate(c)(c, localize(c, correlate(c, err)))
 }
 
-// BadRequest http bad request
-func BadRequest(c *gin.Context, error ...string) {
-	err := NewError(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+// Render writes response for any error: *Error (also wrapped) is written as is, errors with
+// StatusCode() int method get their status and message, everything else is internal server error,
+// messages of 5xx errors without *Error are replaced with status text
+func Render(c *gin.Context, err error) {
+	herr := new(Error)
 
-	if len(error) > 0 {
-		err.Message = error[0]
+	if errors.As(err, &herr) {
+		Respond(c, herr)
+		return
 	}
 
-	c.JSON(err.Status, err)
+	status := http.StatusInternalServerError
+	var coder interface{ StatusCode() int }
+
+	if errors.As(err, &coder) {
+		status = coder.StatusCode()
+	}
+
+	Respond(c, Wrap(status, err))
 }
 
-// Unauthorized http unauthorized
-func Unauthorized(c *gin.Context, error ...string) {
-	err := NewError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+func respond(c *gin.Context, status int, error ...string) {
+	err := NewError(status, http.StatusText(status))
 
 	if len(error) > 0 {
 		err.Message = error[0]
 	}
 
-	c.JSON(err.Status, err)
+	Respond(c, err)
 }
 
-// Forbidden htt forbidden
-func Forbidden(c *gin.Context, error ...string) {
-	err := NewError(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+// NotFound http not found error
+func NotFound(c *gin.Context, error ...string) {
+	respond(c, http.StatusNotFound, error...)
+}
 
-	if len(error) > 0 {
-		err.Message = error[0]
-	}
+// InternalServerError http internal server error
+func InternalServerError(c *gin.Context, error ...string) {
+	respond(c, http.StatusInternalServerError, error...)
+}
 
-	c.JSON(err.Status, err)
+// UnprocessableEntity http unprocessable entity
+func UnprocessableEntity(c *gin.Context, error ...string) {
+	respond(c, http.StatusUnprocessableEntity, error...)
+}
+
+// BadRequest http bad request
+func BadRequest(c *gin.Context, error ...string) {
+	respond(c, http.StatusBadRequest, error...)
+}
+
+// Unauthorized http unauthorized
+func Unauthorized(c *gin.Context, error ...string) {
+	respond(c, http.StatusUnauthorized, error...)
+}
+
+// Forbidden htt forbidden
+func Forbidden(c *gin.Context, error ...string) {
+	respond(c, http.StatusForbidden, error...)
 }
 
 // TooManyRequests http to many requests
 func TooManyRequests(c *gin.Context, error ...string) {
-	err := NewError(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+	respond(c, http.StatusTooManyRequests, error...)
+}
 
-	if len(error) > 0 {
-		err.Message = error[0]
-	}
+// Conflict http conflict
+func Conflict(c *gin.Context, error ...string) {
+	respond(c, http.StatusConflict, error...)
+}
+
+// Gone http gone
+func Gone(c *gin.Context, error ...string) {
+	respond(c, http.StatusGone, error...)
+}
+
+// PreconditionFailed http precondition failed
+func PreconditionFailed(c *gin.Context, error ...string) {
+	respond(c, http.StatusPreconditionFailed, error...)
+}
+
+// RequestEntityTooLarge http request entity too large
+func RequestEntityTooLarge(c *gin.Context, error ...string) {
+	respond(c, http.StatusRequestEntityTooLarge, error...)
+}
+
+// UnsupportedMediaType http unsupported media type
+func UnsupportedMediaType(c *gin.Context, error ...string) {
+	respond(c, http.StatusUnsupportedMediaType, error...)
+}
+
+// BadGateway http bad gateway
+func BadGateway(c *gin.Context, error ...string) {
+	respond(c, http.StatusBadGateway, error...)
+}
+
+// ServiceUnavailable http service unavailable
+func ServiceUnavailable(c *gin.Context, error ...string) {
+	respond(c, http.StatusServiceUnavailable, error...)
+}
 
-	c.JSON(err.Status, err)
+// GatewayTimeout http gateway timeout
+func GatewayTimeout(c *gin.Context, error ...string) {
+	respond(c, http.StatusGatewayTimeout, error...)
 }
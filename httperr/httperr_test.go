@@ -2,6 +2,7 @@ package httperr
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -22,6 +23,14 @@ const httperrBadRequestURL = "/bad-request"
 const httperrUnauthorizedURL = "/unauthorized"
 const httperrForbiddenURL = "/forbidden"
 const httperrToManyReqURL = "/to-many-req"
+const httperrConflictURL = "/conflict"
+const httperrGoneURL = "/gone"
+const httperrPreconditionFailedURL = "/precondition-failed"
+const httperrRequestEntityTooLargeURL = "/request-entity-too-large"
+const httperrUnsupportedMediaTypeURL = "/unsupported-media-type"
+const httperrBadGatewayURL = "/bad-gateway"
+const httperrServiceUnavailableURL = "/service-unavailable"
+const httperrGatewayTimeoutURL = "/gateway-timeout"
 
 func creatErrorTestServer() http.Handler {
 	gin.SetMode(gin.TestMode)
@@ -55,6 +64,38 @@ func creatErrorTestServer() http.Handler {
 		TooManyRequests(c)
 	})
 
+	router.Handle(http.MethodGet, httperrConflictURL, func(c *gin.Context) {
+		Conflict(c)
+	})
+
+	router.Handle(http.MethodGet, httperrGoneURL, func(c *gin.Context) {
+		Gone(c)
+	})
+
+	router.Handle(http.MethodGet, httperrPreconditionFailedURL, func(c *gin.Context) {
+		PreconditionFailed(c)
+	})
+
+	router.Handle(http.MethodGet, httperrRequestEntityTooLargeURL, func(c *gin.Context) {
+		RequestEntityTooLarge(c)
+	})
+
+	router.Handle(http.MethodGet, httperrUnsupportedMediaTypeURL, func(c *gin.Context) {
+		UnsupportedMediaType(c)
+	})
+
+	router.Handle(http.MethodGet, httperrBadGatewayURL, func(c *gin.Context) {
+		BadGateway(c)
+	})
+
+	router.Handle(http.MethodGet, httperrServiceUnavailableURL, func(c *gin.Context) {
+		ServiceUnavailable(c)
+	})
+
+	router.Handle(http.MethodGet, httperrGatewayTimeoutURL, func(c *gin.Context) {
+		GatewayTimeout(c)
+	})
+
 	return router
 }
 
@@ -101,6 +142,38 @@ func TestErrors(t *testing.T) {
 			httperrToManyReqURL,
 			NewError(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests)),
 		},
+		{
+			httperrConflictURL,
+			NewError(http.StatusConflict, http.StatusText(http.StatusConflict)),
+		},
+		{
+			httperrGoneURL,
+			NewError(http.StatusGone, http.StatusText(http.StatusGone)),
+		},
+		{
+			httperrPreconditionFailedURL,
+			NewError(http.StatusPreconditionFailed, http.StatusText(http.StatusPreconditionFailed)),
+		},
+		{
+			httperrRequestEntityTooLargeURL,
+			NewError(http.StatusRequestEntityTooLarge, http.StatusText(http.StatusRequestEntityTooLarge)),
+		},
+		{
+			httperrUnsupportedMediaTypeURL,
+			NewError(http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType)),
+		},
+		{
+			httperrBadGatewayURL,
+			NewError(http.StatusBadGateway, http.StatusText(http.StatusBadGateway)),
+		},
+		{
+			httperrServiceUnavailableURL,
+			NewError(http.StatusServiceUnavailable, http.StatusText(http.StatusServiceUnavailable)),
+		},
+		{
+			httperrGatewayTimeoutURL,
+			NewError(http.StatusGatewayTimeout, http.StatusText(http.StatusGatewayTimeout)),
+		},
 	} {
 		res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, test.URL))
 		assert.NoError(t, err)
@@ -118,3 +191,31 @@ func TestErrors(t *testing.T) {
 		assert.Equal(t, test.Error.Status, res.StatusCode)
 	}
 }
+
+func TestWrap(t *testing.T) {
+	assert := assert.New(t)
+	cause := errors.New("connection refused")
+
+	err := Wrap(http.StatusNotFound, cause)
+	assert.Equal("connection refused", err.Message)
+	assert.Equal(http.StatusNotFound, err.StatusCode())
+	assert.True(errors.Is(err, cause))
+	assert.Equal("connection refused", err.Error())
+
+	err = Wrap(http.StatusBadGateway, cause)
+	assert.Equal(http.StatusText(http.StatusBadGateway), err.Message)
+	assert.Equal(cause, err.Unwrap())
+	assert.Equal("Bad Gateway: connection refused", err.Error())
+	assert.Equal("connection refused", Wrap(http.StatusInternalServerError, cause, cause.Error()).Error())
+
+	err = Wrapf(http.StatusBadRequest, cause, "invalid %s", "id")
+	assert.Equal("invalid id", err.Message)
+	assert.Equal("invalid id: connection refused", err.Error())
+
+	var target *Error
+	assert.True(errors.As(fmt.Errorf("handler: %w", err), &target))
+	assert.Equal(http.StatusBadRequest, target.Status)
+
+	assert.Equal("not found", NewError(http.StatusNotFound, "not found").Error())
+	assert.Nil(NewError(http.StatusNotFound, "not found").Unwrap())
+}
@@ -0,0 +1,205 @@
+package httperr
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle localized messages of a locale keyed by status ("404") or catalog error code ("USER_NOT_FOUND"),
+// error code messages can reference catalog params as {name}
+type Bundle map[string]string
+
+// NewLocalizer creates localizer, defaultLocale ends every fallback chain
+func NewLocalizer(defaultLocale string) *Localizer {
+	return &Localizer{
+		defaultLocale: normalizeLocale(defaultLocale),
+		bundles:       map[string]Bundle{},
+		fallbacks:     map[string][]string{},
+	}
+}
+
+// Localizer message bundles with Accept-Language resolution, safe for concurrent use
+type Localizer struct {
+	defaultLocale string
+	mut           sync.RWMutex
+	bundles       map[string]Bundle
+	fallbacks     map[string][]string
+}
+
+// Add merges messages into the locale bundle
+func (l *Localizer) Add(locale string, bundle Bundle) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	locale = normalizeLocale(locale)
+
+	if l.bundles[locale] == nil {
+		l.bundles[locale] = Bundle{}
+	}
+
+	for key, message := range bundle {
+		l.bundles[locale][key] = message
+	}
+}
+
+// LoadFile adds JSON or YAML bundle file to the locale
+func (l *Localizer) LoadFile(locale string, name string) error {
+	data, err := ioutil.ReadFile(name)
+
+	if err != nil {
+		return err
+	}
+
+	bundle := Bundle{}
+
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return err
+	}
+
+	l.Add(locale, bundle)
+	return nil
+}
+
+// LoadDir adds all *.json, *.yaml and *.yml bundle files from the directory, file name is the locale (e.g. "pt-BR.yaml")
+func (l *Localizer) LoadDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+
+		if file.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		if err := l.LoadFile(strings.TrimSuffix(file.Name(), ext), filepath.Join(dir, file.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetFallback sets locales tried after the locale and its parent (e.g. "pt" for "pt-BR") before the default one
+func (l *Localizer) SetFallback(locale string, fallbacks ...string) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	locales := make([]string, 0, len(fallbacks))
+
+	for _, fallback := range fallbacks {
+		locales = append(locales, normalizeLocale(fallback))
+	}
+
+	l.fallbacks[normalizeLocale(locale)] = locales
+}
+
+// Locales fallback chain for Accept-Language header, ends with the default locale
+func (l *Localizer) Locales(acceptLanguage string) []string {
+	l.mut.RLock()
+	defer l.mut.RUnlock()
+
+	chain := []string{}
+	seen := map[string]bool{}
+	var add func(locale string)
+
+	add = func(locale string) {
+		if len(locale) <= 0 || locale == "*" || seen[locale] {
+			return
+		}
+
+		seen[locale] = true
+		chain = append(chain, locale)
+
+		if i := strings.LastIndex(locale, "-"); i > 0 {
+			add(locale[:i])
+		}
+
+		for _, fallback := range l.fallbacks[locale] {
+			add(fallback)
+		}
+	}
+
+	for _, locale := range parseAccept(acceptLanguage) {
+		add(normalizeLocale(locale))
+	}
+
+	add(l.defaultLocale)
+	return chain
+}
+
+// Message first message for the key found along the locales chain, returns the locale it was found in
+func (l *Localizer) Message(locales []string, key string) (string, string, bool) {
+	l.mut.RLock()
+	defer l.mut.RUnlock()
+
+	for _, locale := range locales {
+		if message, ok := l.bundles[locale][key]; ok {
+			return message, locale, true
+		}
+	}
+
+	return "", "", false
+}
+
+var localizer = struct {
+	sync.RWMutex
+	*Localizer
+}{}
+
+// SetLocalizer sets localizer used by Respond (and all helpers), nil disables localization
+func SetLocalizer(l *Localizer) {
+	localizer.Lock()
+	defer localizer.Unlock()
+
+	localizer.Localizer = l
+}
+
+// localize translates catalog errors by code and errors with default status text by status,
+// explicitly provided messages are left as is, responses vary by Accept-Language when localizer is set
+func localize(c *gin.Context, err *Error) *Error {
+	localizer.RLock()
+	l := localizer.Localizer
+	localizer.RUnlock()
+
+	if l == nil {
+		return err
+	}
+
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
+	locales := l.Locales(c.GetHeader("Accept-Language"))
+	message, locale, ok := "", "", false
+
+	if len(err.Code) > 0 {
+		message, locale, ok = l.Message(locales, err.Code)
+	}
+
+	if !ok && err.Message == http.StatusText(err.Status) {
+		message, locale, ok = l.Message(locales, strconv.Itoa(err.Status))
+	}
+
+	if !ok {
+		return err
+	}
+
+	localized := *err
+	localized.Message = fillParams(message, err.Params)
+	c.Header("Content-Language", locale)
+
+	return &localized
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+}
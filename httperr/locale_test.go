@@ -0,0 +1,95 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizer(t *testing.T) {
+	assert := assert.New(t)
+
+	localizer := NewLocalizer("en")
+	assert.NoError(localizer.LoadDir("testdata/locales"))
+	assert.Error(localizer.LoadDir("testdata/missing"))
+	assert.Error(localizer.LoadFile("de", "testdata/missing.yaml"))
+	assert.Error(localizer.LoadFile("de", "testdata/invalid.yaml"))
+	localizer.Add("en", Bundle{"404": "Nothing here"})
+	localizer.Add("es", Bundle{"429": "Demasiadas solicitudes"})
+	localizer.SetFallback("ca", "es")
+
+	assert.Equal([]string{"en"}, localizer.Locales(""))
+	assert.Equal([]string{"pt-br", "pt", "de", "en"}, localizer.Locales("de;q=0.5, pt_BR, *;q=0.1"))
+	assert.Equal([]string{"ca-es", "ca", "es", "en"}, localizer.Locales("ca-ES"))
+
+	message, locale, ok := localizer.Message(localizer.Locales("pt-BR"), "404")
+	assert.True(ok)
+	assert.Equal("Não encontrado (Brasil)", message)
+	assert.Equal("pt-br", locale)
+
+	message, _, _ = localizer.Message(localizer.Locales("pt-BR"), "429")
+	assert.Equal("Muitas requisições", message)
+
+	message, _, _ = localizer.Message(localizer.Locales("ca"), "429")
+	assert.Equal("Demasiadas solicitudes", message)
+
+	message, _, _ = localizer.Message(localizer.Locales("fr"), "404")
+	assert.Equal("Nothing here", message)
+
+	_, _, ok = localizer.Message(localizer.Locales("fr"), "500")
+	assert.False(ok)
+}
+
+func TestLocalize(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	localizer := NewLocalizer("en")
+	assert.NoError(localizer.LoadDir("testdata/locales"))
+	SetLocalizer(localizer)
+	defer SetLocalizer(nil)
+
+	catalog := NewCatalog()
+	assert.NoError(catalog.Register(CatalogEntry{Code: "USER_NOT_FOUND", Status: http.StatusNotFound, Message: "user {id} not found"}))
+
+	router := gin.New()
+	router.GET("/default", func(c *gin.Context) {
+		NotFound(c)
+	})
+	router.GET("/explicit", func(c *gin.Context) {
+		NotFound(c, "no such page")
+	})
+	router.GET("/catalog", func(c *gin.Context) {
+		Respond(c, catalog.Error("USER_NOT_FOUND", Params{"id": 7}))
+	})
+
+	for _, test := range []struct {
+		url      string
+		language string
+		message  string
+		content  string
+	}{
+		{"/default", "de-AT, en;q=0.8", "Nicht gefunden", "de"},
+		{"/default", "fr", "Not Found", ""},
+		{"/default", "", "Not Found", ""},
+		{"/explicit", "de", "no such page", ""},
+		{"/catalog", "de", "Benutzer 7 wurde nicht gefunden", "de"},
+		{"/catalog", "pt", "user 7 not found", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		req.Header.Set("Accept-Language", test.language)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+
+		herr := new(Error)
+		assert.NoError(json.Unmarshal(res.Body.Bytes(), herr))
+		assert.Equal(http.StatusNotFound, res.Code)
+		assert.Equal(test.message, herr.Message, test.url+" "+test.language)
+		assert.Equal(test.content, res.Header().Get("Content-Language"), test.url+" "+test.language)
+		assert.Equal("Accept-Language", res.Header().Get("Vary"), test.url+" "+test.language)
+	}
+}
@@ -0,0 +1,82 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType media type of problem details documents
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails respond with problem details documents to all requests, not only to ones accepting ProblemContentType
+var ProblemDetails = false
+
+// Problem problem details document, Type defaults to "about:blank", Title to status text,
+// Detail to Message and Instance to the provided one, Code, Params, Fields, RequestID and TraceID are added as extension members
+// and extensions can't override standard members
+func (e *Error) Problem(instance string) map[string]interface{} {
+	doc := map[string]interface{}{}
+
+	for name, value := range e.Extensions {
+		doc[name] = value
+	}
+
+	if len(e.Code) > 0 {
+		doc["code"] = e.Code
+	}
+
+	if len(e.Params) > 0 {
+		doc["params"] = e.Params
+	}
+
+	if len(e.Fields) > 0 {
+		doc["errors"] = e.Fields
+	}
+
+	if len(e.RequestID) > 0 {
+		doc["request_id"] = e.RequestID
+	}
+
+	if len(e.TraceID) > 0 {
+		doc["trace_id"] = e.TraceID
+	}
+
+	doc["type"] = e.Type
+	doc["title"] = e.Title
+	doc["status"] = e.Status
+	doc["detail"] = e.Detail
+	doc["instance"] = e.Instance
+
+	if len(e.Type) <= 0 {
+		doc["type"] = "about:blank"
+	}
+
+	if len(e.Title) <= 0 {
+		doc["title"] = http.StatusText(e.Status)
+	}
+
+	if len(e.Detail) <= 0 {
+		doc["detail"] = e.Message
+	}
+
+	if len(e.Instance) <= 0 {
+		doc["instance"] = instance
+	}
+
+	return doc
+}
+
+type problem struct {
+	doc map[string]interface{}
+}
+
+// Render writes problem document
+func (p problem) Render(w http.ResponseWriter) error {
+	p.WriteContentType(w)
+	return json.NewEncoder(w).Encode(p.doc)
+}
+
+// WriteContentType writes problem content type
+func (p problem) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ProblemContentType)
+}
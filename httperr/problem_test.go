@@ -0,0 +1,94 @@
+package httperr
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const problemTestURL = "/users/1"
+
+func createProblemTestServer() http.Handler {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Handle(http.MethodGet, problemTestURL, func(c *gin.Context) {
+		NotFound(c, "user not found")
+	})
+
+	router.Handle(http.MethodPost, problemTestURL, func(c *gin.Context) {
+		Respond(c, &Error{
+			Type:       "https://example.com/probs/out-of-credit",
+			Title:      "You do not have enough credit.",
+			Status:     http.StatusForbidden,
+			Message:    "Your current balance is 30, but that costs 50.",
+			Instance:   "/account/12345/msgs/abc",
+			Extensions: map[string]interface{}{"balance": 30, "status": 200},
+		})
+	})
+
+	return router
+}
+
+func problemTestGet(t *testing.T, method string, url string, accept string) (*http.Response, map[string]interface{}) {
+	req, err := http.NewRequest(method, url, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept", accept)
+
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	body := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(data, &body))
+
+	return res, body
+}
+
+func TestProblem(t *testing.T) {
+	assert := assert.New(t)
+	srv := httptest.NewServer(createProblemTestServer())
+	defer srv.Close()
+
+	res, body := problemTestGet(t, http.MethodGet, srv.URL+problemTestURL, "application/json")
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.Contains(res.Header.Get("Content-Type"), "application/json")
+	assert.Equal(map[string]interface{}{"status": 404.0, "message": "user not found"}, body)
+
+	res, body = problemTestGet(t, http.MethodGet, srv.URL+problemTestURL, "application/problem+json, application/json")
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.Equal(ProblemContentType, res.Header.Get("Content-Type"))
+	assert.Equal(map[string]interface{}{
+		"type":     "about:blank",
+		"title":    "Not Found",
+		"status":   404.0,
+		"detail":   "user not found",
+		"instance": problemTestURL,
+	}, body)
+
+	res, body = problemTestGet(t, http.MethodPost, srv.URL+problemTestURL, ProblemContentType)
+	assert.Equal(http.StatusForbidden, res.StatusCode)
+	assert.Equal(map[string]interface{}{
+		"type":     "https://example.com/probs/out-of-credit",
+		"title":    "You do not have enough credit.",
+		"status":   403.0,
+		"detail":   "Your current balance is 30, but that costs 50.",
+		"instance": "/account/12345/msgs/abc",
+		"balance":  30.0,
+	}, body)
+
+	ProblemDetails = true
+	defer func() { ProblemDetails = false }()
+
+	res, body = problemTestGet(t, http.MethodGet, srv.URL+problemTestURL, "")
+	assert.Equal(ProblemContentType, res.Header.Get("Content-Type"))
+	assert.Equal("user not found", body["detail"])
+}
@@ -0,0 +1,260 @@
+package httperr
+
+import (
+	"encoding/xml"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Renderer writes error response in a specific format
+type Renderer func(c *gin.Context, err *Error)
+
+// HTMLTemplate template of "text/html" error page, executed with *Error
+var HTMLTemplate = htmltemplate.Must(htmltemplate.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Status}} {{.Title}}</title></head>
+<body>
+<h1>{{.Status}} {{.Title}}</h1>
+<p>{{.Message}}</p>
+{{- if .Fields}}
+<ul>
+{{- range .Fields}}
+<li>{{.Message}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .RequestID}}
+<p><small>Request ID: {{.RequestID}}</small></p>
+{{- end}}
+</body>
+</html>
+`))
+
+// TextTemplate template of "text/plain" error response, executed with *Error
+var TextTemplate = template.Must(template.New("error").Parse(`{{.Status}} {{.Title}}: {{.Message}}
+{{range .Fields}}{{.Field}}: {{.Message}}
+{{end}}{{if .RequestID}}request id: {{.RequestID}}
+{{end}}`))
+
+type renderer struct {
+	mediaType string
+	render    Renderer
+}
+
+var renderers = struct {
+	sync.RWMutex
+	list []renderer
+}{
+	list: []renderer{
+		{"application/json", renderJSON},
+		{ProblemContentType, renderProblem},
+		{"application/xml", renderXML("application/xml")},
+		{"text/plain", renderText},
+		{"text/xml", renderXML("text/xml")},
+		{"text/html", renderHTML},
+	},
+}
+
+// RegisterRenderer adds renderer for the media type or replaces existing one,
+// "application/json" renderer is used when client accepts none of the registered media types
+func RegisterRenderer(mediaType string, render Renderer) {
+	renderers.Lock()
+	defer renderers.Unlock()
+
+	for i, existing := range renderers.list {
+		if existing.mediaType == mediaType {
+			renderers.list[i].render = render
+			return
+		}
+	}
+
+	renderers.list = append(renderers.list, renderer{mediaType, render})
+}
+
+// negotiate picks renderer for the Accept header with respect to quality values
+func negotiate(c *gin.Context) Renderer {
+	if ProblemDetails {
+		return renderProblem
+	}
+
+	renderers.RLock()
+	defer renderers.RUnlock()
+
+	for _, accepted := range parseAccept(c.GetHeader("Accept")) {
+		for _, offer := range renderers.list {
+			if matchMediaType(accepted, offer.mediaType) {
+				return offer.render
+			}
+		}
+	}
+
+	return renderers.list[0].render
+}
+
+type acceptItem struct {
+	mediaType string
+	quality   float64
+}
+
+func parseAccept(header string) []string {
+	items := []acceptItem{}
+
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		item := acceptItem{strings.ToLower(strings.TrimSpace(params[0])), 1}
+
+		if len(item.mediaType) <= 0 {
+			continue
+		}
+
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+
+			if strings.HasPrefix(param, "q=") {
+				if quality, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					item.quality = quality
+				}
+			}
+		}
+
+		if item.quality > 0 {
+			items = append(items, item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].quality > items[j].quality
+	})
+
+	types := make([]string, 0, len(items))
+
+	for _, item := range items {
+		types = append(types, item.mediaType)
+	}
+
+	return types
+}
+
+func matchMediaType(accepted string, offer string) bool {
+	if accepted == offer || accepted == "*/*" || accepted == "*" {
+		return true
+	}
+
+	return strings.HasSuffix(accepted, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(accepted, "*"))
+}
+
+func errorTitle(err *Error) string {
+	if len(err.Title) > 0 {
+		return err.Title
+	}
+
+	return http.StatusText(err.Status)
+}
+
+func renderJSON(c *gin.Context, err *Error) {
+	c.JSON(err.Status, err)
+}
+
+func renderProblem(c *gin.Context, err *Error) {
+	c.Render(err.Status, problem{err.Problem(c.Request.URL.Path)})
+}
+
+type xmlParam struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlParams struct {
+	Params []xmlParam `xml:"param"`
+}
+
+type xmlFields struct {
+	Fields []FieldError `xml:"error"`
+}
+
+type xmlError struct {
+	XMLName   xml.Name   `xml:"error"`
+	Type      string     `xml:"type,omitempty"`
+	Title     string     `xml:"title,omitempty"`
+	Status    int        `xml:"status"`
+	Message   string     `xml:"message"`
+	Code      string     `xml:"code,omitempty"`
+	Params    *xmlParams `xml:"params,omitempty"`
+	Fields    *xmlFields `xml:"errors,omitempty"`
+	Detail    string     `xml:"detail,omitempty"`
+	Instance  string     `xml:"instance,omitempty"`
+	RequestID string     `xml:"request_id,omitempty"`
+	TraceID   string     `xml:"trace_id,omitempty"`
+}
+
+func renderXML(contentType string) Renderer {
+	return func(c *gin.Context, err *Error) {
+		doc := xmlError{
+			Type:      err.Type,
+			Title:     err.Title,
+			Status:    err.Status,
+			Message:   err.Message,
+			Code:      err.Code,
+			Detail:    err.Detail,
+			Instance:  err.Instance,
+			RequestID: err.RequestID,
+			TraceID:   err.TraceID,
+		}
+
+		if len(err.Params) > 0 {
+			doc.Params = new(xmlParams)
+
+			for name, value := range err.Params {
+				doc.Params.Params = append(doc.Params.Params, xmlParam{name, fmt.Sprint(value)})
+			}
+
+			sort.Slice(doc.Params.Params, func(i, j int) bool {
+				return doc.Params.Params[i].Name < doc.Params.Params[j].Name
+			})
+		}
+
+		if len(err.Fields) > 0 {
+			doc.Fields = &xmlFields{err.Fields}
+		}
+
+		data, merr := xml.Marshal(doc)
+
+		if merr != nil {
+			c.Error(merr)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		c.Data(err.Status, contentType+"; charset=utf-8", data)
+	}
+}
+
+func renderText(c *gin.Context, err *Error) {
+	data := *err
+	data.Title = errorTitle(err)
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Status(err.Status)
+
+	if terr := TextTemplate.Execute(c.Writer, &data); terr != nil {
+		c.Error(terr)
+	}
+}
+
+func renderHTML(c *gin.Context, err *Error) {
+	data := *err
+	data.Title = errorTitle(err)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(err.Status)
+
+	if terr := HTMLTemplate.Execute(c.Writer, &data); terr != nil {
+		c.Error(terr)
+	}
+}
@@ -0,0 +1,128 @@
+package httperr
+
+import (
+	htmltemplate "html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRenderTestServer() http.Handler {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/users/:id", func(c *gin.Context) {
+		err := NewError(http.StatusNotFound, "user <1> not found")
+		err.Code = "USER_NOT_FOUND"
+		err.Params = Params{"id": 1}
+		Respond(c, err)
+	})
+
+	return router
+}
+
+func renderTestGet(handler http.Handler, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set("Accept", accept)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	return res
+}
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+	handler := createRenderTestServer()
+
+	for _, test := range []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			"",
+			"application/json; charset=utf-8",
+			`{"status":404,"message":"user <1> not found","code":"USER_NOT_FOUND","params":{"id":1}}`,
+		},
+		{
+			"*/*",
+			"application/json; charset=utf-8",
+			`{"status":404,"message":"user <1> not found","code":"USER_NOT_FOUND","params":{"id":1}}`,
+		},
+		{
+			"image/png",
+			"application/json; charset=utf-8",
+			`{"status":404,"message":"user <1> not found","code":"USER_NOT_FOUND","params":{"id":1}}`,
+		},
+		{
+			"application/xml",
+			"application/xml; charset=utf-8",
+			`<error><status>404</status><message>user &lt;1&gt; not found</message><code>USER_NOT_FOUND</code><params><param name="id">1</param></params></error>`,
+		},
+		{
+			"text/plain",
+			"text/plain; charset=utf-8",
+			"404 Not Found: user <1> not found\n",
+		},
+		{
+			"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			"text/html; charset=utf-8",
+			"<!DOCTYPE html>\n<html>\n<head><title>404 Not Found</title></head>\n<body>\n<h1>404 Not Found</h1>\n<p>user &lt;1&gt; not found</p>\n</body>\n</html>\n",
+		},
+		{
+			"text/plain;q=0.5, application/xml;q=0.1",
+			"text/plain; charset=utf-8",
+			"404 Not Found: user <1> not found\n",
+		},
+		{
+			"text/*;q=0.5, application/json;q=0",
+			"text/plain; charset=utf-8",
+			"404 Not Found: user <1> not found\n",
+		},
+		{
+			"text/xml",
+			"text/xml; charset=utf-8",
+			`<error><status>404</status><message>user &lt;1&gt; not found</message><code>USER_NOT_FOUND</code><params><param name="id">1</param></params></error>`,
+		},
+	} {
+		res := renderTestGet(handler, test.accept)
+		assert.Equal(http.StatusNotFound, res.Code, test.accept)
+		assert.Equal(test.contentType, res.Header().Get("Content-Type"), test.accept)
+
+		if strings.HasPrefix(test.contentType, "application/json") {
+			assert.JSONEq(test.body, res.Body.String(), test.accept)
+		} else {
+			assert.Equal(test.body, res.Body.String(), test.accept)
+		}
+	}
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	assert := assert.New(t)
+	handler := createRenderTestServer()
+
+	RegisterRenderer("application/vnd.api+json", func(c *gin.Context, err *Error) {
+		c.Data(err.Status, "application/vnd.api+json", []byte(`{"errors":[{"code":"`+err.Code+`"}]}`))
+	})
+	defer func() {
+		renderers.Lock()
+		renderers.list = renderers.list[:len(renderers.list)-1]
+		renderers.Unlock()
+	}()
+
+	res := renderTestGet(handler, "application/vnd.api+json")
+	assert.Equal("application/vnd.api+json", res.Header().Get("Content-Type"))
+	assert.Equal(`{"errors":[{"code":"USER_NOT_FOUND"}]}`, res.Body.String())
+
+	htmlTemplate := HTMLTemplate
+	HTMLTemplate = htmltemplate.Must(htmltemplate.New("error").Parse(`<p>{{.Code}}</p>`))
+	defer func() { HTMLTemplate = htmlTemplate }()
+
+	res = renderTestGet(handler, "text/html")
+	assert.Equal(`<p>USER_NOT_FOUND</p>`, res.Body.String())
+}
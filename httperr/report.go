@@ -0,0 +1,179 @@
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Report 5xx response with its cause and request details
+// Repeated is the number of identical reports suppressed before this one, see NewDedupReporter
+type Report struct {
+	Time      time.Time `json:"time"`
+	Status    int       `json:"status"`
+	Message   string    `json:"message"`
+	Cause     string    `json:"cause,omitempty"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	TraceID   string    `json:"trace_id,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	Repeated  int       `json:"repeated,omitempty"`
+}
+
+// Reporter receives reports of every 5xx response written by Respond (and all helpers)
+type Reporter interface {
+	Report(report *Report)
+}
+
+// ReporterFunc function adapter for Reporter
+type ReporterFunc func(report *Report)
+
+// Report calls the function
+func (fn ReporterFunc) Report(report *Report) {
+	fn(report)
+}
+
+var reporter = struct {
+	sync.RWMutex
+	Reporter
+}{}
+
+// SetReporter sets reporter of 5xx responses, nil disables reporting
+func SetReporter(r Reporter) {
+	reporter.Lock()
+	defer reporter.Unlock()
+
+	reporter.Reporter = r
+}
+
+// report sends 5xx error to the reporter, user is taken from context "user" key
+func report(c *gin.Context, err *Error) {
+	if err.Status < http.StatusInternalServerError {
+		return
+	}
+
+	reporter.RLock()
+	r := reporter.Reporter
+	reporter.RUnlock()
+
+	if r == nil {
+		return
+	}
+
+	rep := &Report{
+		Time:      time.Now(),
+		Status:    err.Status,
+		Message:   err.Message,
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.Path,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: RequestID(c),
+		TraceID:   TraceID(c),
+	}
+
+	if err.cause != nil {
+		rep.Cause = err.cause.Error()
+	}
+
+	if model, ok := c.Get("user"); ok {
+		if user, ok := model.(interface{ GetUsername() string }); ok {
+			rep.Username = user.GetUsername()
+		}
+	}
+
+	r.Report(rep)
+}
+
+// MultiReporter sends reports to all reporters
+func MultiReporter(reporters ...Reporter) Reporter {
+	return ReporterFunc(func(report *Report) {
+		for _, r := range reporters {
+			r.Report(report)
+		}
+	})
+}
+
+// NewJSONReporter creates reporter writing reports as JSON lines, e.g. to os.Stdout
+func NewJSONReporter(w io.Writer) Reporter {
+	mut := sync.Mutex{}
+
+	return ReporterFunc(func(report *Report) {
+		data, err := json.Marshal(report)
+
+		if err != nil {
+			return
+		}
+
+		mut.Lock()
+		defer mut.Unlock()
+
+		fmt.Fprintln(w, string(data))
+	})
+}
+
+// NewDedupReporter creates reporter that passes identical reports (same status, method, path, message and cause)
+// at most once per interval, when the interval expires number of suppressed reports is sent
+// with the last suppressed one (in Repeated) or with the next passed one, whichever comes first
+func NewDedupReporter(r Reporter, interval time.Duration) Reporter {
+	type seen struct {
+		time       time.Time
+		suppressed int
+		last       *Report
+		timer      *time.Timer
+	}
+
+	mut := sync.Mutex{}
+	reports := map[string]*seen{}
+
+	flush := func(key string, s *seen) {
+		mut.Lock()
+
+		if reports[key] != s {
+			mut.Unlock()
+			return
+		}
+
+		delete(reports, key)
+		mut.Unlock()
+
+		if s.suppressed > 0 {
+			last := *s.last
+			last.Repeated = s.suppressed
+			r.Report(&last)
+		}
+	}
+
+	return ReporterFunc(func(report *Report) {
+		key := fmt.Sprintf("%d %s %s %s %s", report.Status, report.Method, report.Path, report.Message, report.Cause)
+
+		mut.Lock()
+
+		if s, ok := reports[key]; ok && report.Time.Sub(s.time) < interval {
+			s.suppressed++
+			s.last = report
+			mut.Unlock()
+			return
+		} else if ok {
+			s.timer.Stop()
+			report.Repeated = s.suppressed
+		}
+
+		s := &seen{time: report.Time}
+		s.timer = time.AfterFunc(interval, func() {
+			flush(key, s)
+		})
+		reports[key] = s
+		mut.Unlock()
+
+		r.Report(report)
+	})
+}
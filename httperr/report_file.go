@@ -0,0 +1,131 @@
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultMaxFileSize size of the report file that triggers rotation
+const DefaultMaxFileSize = 10 * 1024 * 1024
+
+// FileReporterParams file reporter parameters
+// MaxSize defaults to DefaultMaxFileSize, MaxBackups is number of rotated files to keep (name.1, name.2, ...)
+type FileReporterParams struct {
+	Name       string
+	MaxSize    int64
+	MaxBackups int
+}
+
+// NewFileReporter creates reporter writing reports as JSON lines to a rotating file
+func NewFileReporter(p *FileReporterParams) (*FileReporter, error) {
+	fr := &FileReporter{
+		name:       p.Name,
+		maxSize:    p.MaxSize,
+		maxBackups: p.MaxBackups,
+	}
+
+	if fr.maxSize <= 0 {
+		fr.maxSize = DefaultMaxFileSize
+	}
+
+	return fr, fr.open()
+}
+
+// FileReporter reporter writing to a rotating file
+type FileReporter struct {
+	name       string
+	maxSize    int64
+	maxBackups int
+	mut        sync.Mutex
+	file       *os.File
+	size       int64
+}
+
+func (fr *FileReporter) open() error {
+	file, err := os.OpenFile(fr.name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	fr.file = file
+	fr.size = info.Size()
+	return nil
+}
+
+func (fr *FileReporter) rotate() error {
+	if err := fr.file.Close(); err != nil {
+		return err
+	}
+
+	if fr.maxBackups <= 0 {
+		if err := os.Remove(fr.name); err != nil {
+			return err
+		}
+
+		return fr.open()
+	}
+
+	os.Remove(fmt.Sprintf("%s.%d", fr.name, fr.maxBackups))
+
+	for i := fr.maxBackups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", fr.name, i), fmt.Sprintf("%s.%d", fr.name, i+1))
+	}
+
+	if err := os.Rename(fr.name, fr.name+".1"); err != nil {
+		return err
+	}
+
+	return fr.open()
+}
+
+// Report writes report to the file, rotates the file when it would exceed max size
+func (fr *FileReporter) Report(report *Report) {
+	data, err := json.Marshal(report)
+
+	if err != nil {
+		return
+	}
+
+	data = append(data, '\n')
+
+	fr.mut.Lock()
+	defer fr.mut.Unlock()
+
+	if fr.file == nil {
+		return
+	}
+
+	if fr.size > 0 && fr.size+int64(len(data)) > fr.maxSize {
+		if err := fr.rotate(); err != nil {
+			fr.file = nil
+			return
+		}
+	}
+
+	n, _ := fr.file.Write(data)
+	fr.size += int64(n)
+}
+
+// Close closes the file
+func (fr *FileReporter) Close() error {
+	fr.mut.Lock()
+	defer fr.mut.Unlock()
+
+	if fr.file == nil {
+		return nil
+	}
+
+	err := fr.file.Close()
+	fr.file = nil
+	return err
+}
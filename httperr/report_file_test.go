@@ -0,0 +1,46 @@
+package httperr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileReporter(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "httperr")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "errors.log")
+	reporter, err := NewFileReporter(&FileReporterParams{
+		Name:       name,
+		MaxSize:    200,
+		MaxBackups: 2,
+	})
+	assert.NoError(err)
+
+	for i := 0; i < 10; i++ {
+		reporter.Report(&Report{Status: 500, Message: "Internal Server Error", Cause: strings.Repeat("x", 50)})
+	}
+
+	assert.NoError(reporter.Close())
+	assert.NoError(reporter.Close())
+
+	for _, file := range []string{name, name + ".1", name + ".2"} {
+		data, err := ioutil.ReadFile(file)
+		assert.NoError(err)
+		assert.True(len(data) > 0 && len(data) <= 200, file)
+		assert.True(strings.HasSuffix(string(data), "}\n"), file)
+	}
+
+	_, err = os.Stat(name + ".3")
+	assert.True(os.IsNotExist(err))
+
+	_, err = NewFileReporter(&FileReporterParams{Name: filepath.Join(dir, "missing", "errors.log")})
+	assert.Error(err)
+}
@@ -0,0 +1,134 @@
+package httperr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type reportTestUser struct{}
+
+func (u *reportTestUser) GetUsername() string {
+	return "john"
+}
+
+func TestReport(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	reports := []*Report{}
+	SetReporter(ReporterFunc(func(report *Report) {
+		reports = append(reports, report)
+	}))
+	defer SetReporter(nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", new(reportTestUser))
+		c.Set("request_id", "abc-123")
+	})
+	router.GET("/internal", func(c *gin.Context) {
+		Render(c, errors.New("db is down"))
+	})
+	router.GET("/helper", func(c *gin.Context) {
+		InternalServerError(c)
+	})
+	router.GET("/not-found", func(c *gin.Context) {
+		NotFound(c)
+	})
+
+	for _, url := range []string{"/internal", "/helper", "/not-found"} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("User-Agent", "test")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Len(reports, 2)
+	assert.Equal(http.StatusInternalServerError, reports[0].Status)
+	assert.Equal(http.StatusText(http.StatusInternalServerError), reports[0].Message)
+	assert.Equal("db is down", reports[0].Cause)
+	assert.Equal(http.MethodGet, reports[0].Method)
+	assert.Equal("/internal", reports[0].Path)
+	assert.Equal("test", reports[0].UserAgent)
+	assert.Equal("abc-123", reports[0].RequestID)
+	assert.Equal("john", reports[0].Username)
+	assert.Equal("/helper", reports[1].Path)
+	assert.Empty(reports[1].Cause)
+}
+
+func TestJSONReporter(t *testing.T) {
+	assert := assert.New(t)
+	buf := new(bytes.Buffer)
+
+	NewJSONReporter(buf).Report(&Report{Status: http.StatusBadGateway, Message: "Bad Gateway", Cause: "timeout"})
+
+	report := new(Report)
+	assert.NoError(json.Unmarshal(buf.Bytes(), report))
+	assert.Equal("timeout", report.Cause)
+	assert.Equal(byte('\n'), buf.Bytes()[buf.Len()-1])
+}
+
+func TestDedupReporter(t *testing.T) {
+	assert := assert.New(t)
+
+	reports := []Report{}
+	collect := ReporterFunc(func(report *Report) {
+		reports = append(reports, *report)
+	})
+	multi := MultiReporter(collect, ReporterFunc(func(report *Report) {}))
+	reporter := NewDedupReporter(multi, time.Minute)
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		reporter.Report(&Report{Time: now.Add(time.Second * time.Duration(i)), Status: 500, Path: "/a", Cause: "db is down"})
+	}
+
+	reporter.Report(&Report{Time: now, Status: 500, Path: "/b", Cause: "db is down"})
+	reporter.Report(&Report{Time: now.Add(time.Minute * 2), Status: 500, Path: "/a", Cause: "db is down"})
+
+	assert.Len(reports, 3)
+	assert.Equal("/a", reports[0].Path)
+	assert.Equal(0, reports[0].Repeated)
+	assert.Equal("/b", reports[1].Path)
+	assert.Equal("/a", reports[2].Path)
+	assert.Equal(4, reports[2].Repeated)
+}
+
+func TestDedupReporterFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	mut := sync.Mutex{}
+	reports := []Report{}
+	reporter := NewDedupReporter(ReporterFunc(func(report *Report) {
+		mut.Lock()
+		defer mut.Unlock()
+
+		reports = append(reports, *report)
+	}), time.Millisecond*50)
+
+	for i := 0; i < 3; i++ {
+		reporter.Report(&Report{Time: time.Now(), Status: 500, Path: "/a", Cause: "db is down"})
+	}
+
+	assert.Eventually(func() bool {
+		mut.Lock()
+		defer mut.Unlock()
+
+		return len(reports) == 2
+	}, time.Second, time.Millisecond*10)
+
+	mut.Lock()
+	defer mut.Unlock()
+
+	assert.Equal(0, reports[0].Repeated)
+	assert.Equal("/a", reports[1].Path)
+	assert.Equal(2, reports[1].Repeated)
+}
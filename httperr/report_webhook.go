@@ -0,0 +1,91 @@
+package httperr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultWebhookTimeout timeout of webhook requests when client is not provided
+const DefaultWebhookTimeout = time.Second * 5
+
+// WebhookQueueSize number of reports waiting to be sent, reports over it are dropped
+const WebhookQueueSize = 100
+
+// ErrWebhookQueueFull report was dropped because too many reports are waiting to be sent
+var ErrWebhookQueueFull = errors.New("webhook queue is full")
+
+// NewWebhookReporter creates reporter posting reports as JSON to the url
+// client defaults to http.Client with DefaultWebhookTimeout, failed deliveries and dropped reports
+// are passed to onError (can be nil)
+func NewWebhookReporter(url string, client *http.Client, onError func(err error)) *WebhookReporter {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+
+	wr := &WebhookReporter{
+		url:     url,
+		client:  client,
+		onError: onError,
+		queue:   make(chan []byte, WebhookQueueSize),
+	}
+
+	go wr.work()
+
+	return wr
+}
+
+// WebhookReporter reporter sending reports to http endpoint one by one in background
+type WebhookReporter struct {
+	url     string
+	client  *http.Client
+	onError func(err error)
+	queue   chan []byte
+}
+
+// Report queues report, drops it with ErrWebhookQueueFull when the queue is full
+func (wr *WebhookReporter) Report(report *Report) {
+	data, err := json.Marshal(report)
+
+	if err != nil {
+		wr.fail(err)
+		return
+	}
+
+	select {
+	case wr.queue <- data:
+	default:
+		wr.fail(ErrWebhookQueueFull)
+	}
+}
+
+func (wr *WebhookReporter) work() {
+	for data := range wr.queue {
+		wr.fail(wr.send(data))
+	}
+}
+
+func (wr *WebhookReporter) send(data []byte) error {
+	res, err := wr.client.Post(wr.url, "application/json", bytes.NewReader(data))
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (wr *WebhookReporter) fail(err error) {
+	if err != nil && wr.onError != nil {
+		wr.onError(err)
+	}
+}
@@ -0,0 +1,81 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookReporter(t *testing.T) {
+	assert := assert.New(t)
+	received := make(chan *Report, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		report := new(Report)
+
+		if err := json.NewDecoder(r.Body).Decode(report); err != nil || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		received <- report
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	NewWebhookReporter(srv.URL, nil, func(err error) {
+		assert.NoError(err)
+	}).Report(&Report{Status: 500, Path: "/users", Cause: "db is down"})
+
+	select {
+	case report := <-received:
+		assert.Equal("/users", report.Path)
+		assert.Equal("db is down", report.Cause)
+	case <-time.After(time.Second * 5):
+		assert.Fail("webhook was not called")
+	}
+
+	errs := make(chan error, 1)
+	NewWebhookReporter(srv.URL+"/missing", srv.Client(), func(err error) {
+		errs <- err
+	}).Report(&Report{Status: 500})
+
+	select {
+	case err := <-errs:
+		assert.EqualError(err, "webhook responded with status 404")
+	case <-time.After(time.Second * 5):
+		assert.Fail("webhook error was not reported")
+	}
+
+	block := make(chan struct{})
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer blocked.Close()
+	defer close(block)
+
+	dropped := make(chan error, WebhookQueueSize*2)
+	reporter := NewWebhookReporter(blocked.URL, blocked.Client(), func(err error) {
+		dropped <- err
+	})
+
+	for i := 0; i < WebhookQueueSize+2; i++ {
+		reporter.Report(&Report{Status: 500})
+	}
+
+	select {
+	case err := <-dropped:
+		assert.Equal(ErrWebhookQueueFull, err)
+	case <-time.After(time.Second * 5):
+		assert.Fail("queue overflow was not reported")
+	}
+}
@@ -0,0 +1,68 @@
+package httperr
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader header carrying request ID
+const RequestIDHeader = "X-Request-ID"
+
+// TraceIDHeader response header carrying trace ID
+const TraceIDHeader = "X-Trace-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// ValidRequestID checks that request ID is 1-128 letters, digits, ".", "_", ":" or "-"
+func ValidRequestID(id string) bool {
+	return requestIDPattern.MatchString(id)
+}
+
+// RequestID current request ID set under "request_id" key (see httpmw.RequestID),
+// falls back to X-Request-ID request header when it is a valid request ID
+func RequestID(c *gin.Context) string {
+	if id := c.GetString("request_id"); len(id) > 0 {
+		return id
+	}
+
+	if id := c.GetHeader(RequestIDHeader); ValidRequestID(id) {
+		return id
+	}
+
+	return ""
+}
+
+// TraceID current trace ID set under "trace_id" key (see httpmw.RequestID)
+func TraceID(c *gin.Context) string {
+	return c.GetString("trace_id")
+}
+
+// correlate adds request and trace IDs to the error and response headers
+func correlate(c *gin.Context, err *Error) *Error {
+	requestID, traceID := RequestID(c), TraceID(c)
+
+	if len(requestID) <= 0 && len(traceID) <= 0 {
+		return err
+	}
+
+	correlated := *err
+
+	if len(requestID) > 0 {
+		c.Header(RequestIDHeader, requestID)
+
+		if len(correlated.RequestID) <= 0 {
+			correlated.RequestID = requestID
+		}
+	}
+
+	if len(traceID) > 0 {
+		c.Header(TraceIDHeader, traceID)
+
+		if len(correlated.TraceID) <= 0 {
+			correlated.TraceID = traceID
+		}
+	}
+
+	return &correlated
+}
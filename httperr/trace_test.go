@@ -0,0 +1,60 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelation(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/traced", func(c *gin.Context) {
+		c.Set("request_id", "abc-123")
+		c.Set("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736")
+		NotFound(c)
+	})
+	router.GET("/header", func(c *gin.Context) {
+		BadRequest(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/traced", nil)
+	req.Header.Set("Accept", ProblemContentType)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	body := map[string]interface{}{}
+	assert.NoError(json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Equal("abc-123", body["request_id"])
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", body["trace_id"])
+	assert.Equal("abc-123", res.Header().Get(RequestIDHeader))
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", res.Header().Get(TraceIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/header", nil)
+	req.Header.Set(RequestIDHeader, "from-client")
+	req.Header.Set("Accept", "text/plain")
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	assert.Equal("400 Bad Request: Bad Request\nrequest id: from-client\n", res.Body.String())
+	assert.Equal("from-client", res.Header().Get(RequestIDHeader))
+	assert.Empty(res.Header().Get(TraceIDHeader))
+
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/header", nil))
+	assert.Equal(`{"status":400,"message":"Bad Request"}`, res.Body.String())
+	assert.Empty(res.Header().Get(RequestIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/header", nil)
+	req.Header.Set(RequestIDHeader, "<script>alert(1)</script>")
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(`{"status":400,"message":"Bad Request"}`, res.Body.String())
+	assert.Empty(res.Header().Get(RequestIDHeader))
+}
@@ -0,0 +1,157 @@
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError invalid request field
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Rule    string `json:"rule" xml:"rule"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+// ValidationMessages message templates by validation rule, {field} and {param} are replaced with the actual values
+// "json" rule is used for malformed request body and "type" rule for values of the wrong type
+var ValidationMessages = map[string]string{
+	"json":     "request body is not valid JSON",
+	"type":     "{field} should be of type {param}",
+	"required": "{field} is required",
+	"email":    "{field} should be a valid email",
+	"url":      "{field} should be a valid URL",
+	"uuid":     "{field} should be a valid UUID",
+	"min":      "{field} should be at least {param}",
+	"max":      "{field} should be at most {param}",
+	"len":      "{field} should have length {param}",
+	"gt":       "{field} should be greater than {param}",
+	"gte":      "{field} should be greater than or equal to {param}",
+	"lt":       "{field} should be less than {param}",
+	"lte":      "{field} should be less than or equal to {param}",
+	"eq":       "{field} should be equal to {param}",
+	"ne":       "{field} should not be equal to {param}",
+	"oneof":    "{field} should be one of {param}",
+}
+
+// DefaultValidationMessage template for rules missing in ValidationMessages
+var DefaultValidationMessage = "{field} is invalid"
+
+// UseJSONFieldNames names fields of binding validation errors after their json tags ("address.street" instead of "Address.Street"),
+// it changes field names reported by gin default validator for the whole application, so it should be called on startup
+// before any request is bound, returns false when binding.Validator is not based on go-playground validator
+func UseJSONFieldNames() bool {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+
+	if ok {
+		engine.RegisterTagNameFunc(jsonTagName)
+	}
+
+	return ok
+}
+
+func jsonTagName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// Validation responds with field errors for request binding errors: malformed JSON is a bad request,
+// validation and type errors are unprocessable entity, other errors are rendered as unprocessable entity too
+func Validation(c *gin.Context, err error) {
+	fields, ok := FieldErrors(err)
+
+	if !ok {
+		UnprocessableEntity(c, err.Error())
+		return
+	}
+
+	status := http.StatusUnprocessableEntity
+
+	if len(fields) == 1 && fields[0].Rule == "json" {
+		status = http.StatusBadRequest
+	}
+
+	messages := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		messages = append(messages, field.Message)
+	}
+
+	herr := Wrap(status, err, strings.Join(messages, "; "))
+	herr.Fields = fields
+	Respond(c, herr)
+}
+
+// FieldErrors converts binding error to field errors, fields are named by their json tags (e.g. "address.street")
+// when enabled with UseJSONFieldNames and by struct fields otherwise
+// returns false for errors that are not validation or JSON decoding ones
+func FieldErrors(err error) ([]FieldError, bool) {
+	var verrs validator.ValidationErrors
+
+	if errors.As(err, &verrs) {
+		fields := make([]FieldError, 0, len(verrs))
+
+		for _, verr := range verrs {
+			fields = append(fields, newFieldError(fieldNamespace(verr.Namespace()), verr.Tag(), verr.Param()))
+		}
+
+		return fields, true
+	}
+
+	var typeErr *json.UnmarshalTypeError
+
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+
+		if len(field) <= 0 {
+			field = "body"
+		}
+
+		return []FieldError{newFieldError(field, "type", typeErr.Type.String())}, true
+	}
+
+	var syntaxErr *json.SyntaxError
+
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return []FieldError{newFieldError("body", "json", "")}, true
+	}
+
+	return nil, false
+}
+
+func newFieldError(field string, rule string, param string) FieldError {
+	message, ok := ValidationMessages[rule]
+
+	if !ok {
+		message = DefaultValidationMessage
+	}
+
+	return FieldError{
+		Field:   field,
+		Rule:    rule,
+		Param:   param,
+		Message: strings.NewReplacer("{field}", field, "{param}", param).Replace(message),
+	}
+}
+
+// fieldNamespace strips the struct name from "User.address.street" namespace
+func fieldNamespace(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return namespace
+}
@@ -0,0 +1,112 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type validationTestAddress struct {
+	Street string `json:"street_name" binding:"required"`
+}
+
+type validationTestItem struct {
+	Quantity int `json:"qty" binding:"min=1"`
+}
+
+type validationTestUser struct {
+	Name    string                 `json:"name" binding:"required"`
+	Email   string                 `json:"email" binding:"required,email"`
+	Age     int                    `json:"age" binding:"gte=18"`
+	Role    string                 `binding:"omitempty,oneof=admin user"`
+	Address *validationTestAddress `json:"address" binding:"required"`
+	Items   []validationTestItem   `json:"items" binding:"dive"`
+}
+
+func createValidationTestServer() http.Handler {
+	gin.SetMode(gin.TestMode)
+	UseJSONFieldNames()
+	router := gin.New()
+
+	router.POST("/users", func(c *gin.Context) {
+		user := new(validationTestUser)
+
+		if err := c.ShouldBindJSON(user); err != nil {
+			Validation(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	})
+
+	return router
+}
+
+func validationTestPost(handler http.Handler, url string, body string) (int, *Error) {
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodPost, url, strings.NewReader(body)))
+
+	herr := new(Error)
+
+	if res.Code != http.StatusNoContent {
+		json.Unmarshal(res.Body.Bytes(), herr)
+	}
+
+	return res.Code, herr
+}
+
+func TestValidation(t *testing.T) {
+	assert := assert.New(t)
+	handler := createValidationTestServer()
+
+	status, herr := validationTestPost(handler, "/users", `{"email":"nope","age":10,"Role":"root","address":{},"items":[{"qty":1},{"qty":0}]}`)
+	assert.Equal(http.StatusUnprocessableEntity, status)
+	assert.Equal([]FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "email", Rule: "email", Message: "email should be a valid email"},
+		{Field: "age", Rule: "gte", Param: "18", Message: "age should be greater than or equal to 18"},
+		{Field: "Role", Rule: "oneof", Param: "admin user", Message: "Role should be one of admin user"},
+		{Field: "address.street_name", Rule: "required", Message: "address.street_name is required"},
+		{Field: "items[1].qty", Rule: "min", Param: "1", Message: "items[1].qty should be at least 1"},
+	}, herr.Fields)
+	assert.Contains(herr.Message, "name is required; ")
+
+	status, herr = validationTestPost(handler, "/users", `{"name":"john","age":"old"}`)
+	assert.Equal(http.StatusUnprocessableEntity, status)
+	assert.Equal([]FieldError{{Field: "age", Rule: "type", Param: "int", Message: "age should be of type int"}}, herr.Fields)
+
+	status, herr = validationTestPost(handler, "/users", `{"name":`)
+	assert.Equal(http.StatusBadRequest, status)
+	assert.Equal([]FieldError{{Field: "body", Rule: "json", Message: "request body is not valid JSON"}}, herr.Fields)
+
+	status, _ = validationTestPost(handler, "/users", `{"name":"john","email":"john@example.com","age":20,"address":{"street_name":"main"}}`)
+	assert.Equal(http.StatusNoContent, status)
+}
+
+func TestValidationMessages(t *testing.T) {
+	assert := assert.New(t)
+	handler := createValidationTestServer()
+
+	ValidationMessages["required"] = "{field} is missing"
+	defer func() { ValidationMessages["required"] = "{field} is required" }()
+
+	_, herr := validationTestPost(handler, "/users", `{"email":"john@example.com","age":20,"address":{"street_name":"main"}}`)
+	assert.Equal([]FieldError{{Field: "name", Rule: "required", Message: "name is missing"}}, herr.Fields)
+
+	fields, ok := FieldErrors(http.ErrBodyNotAllowed)
+	assert.False(ok)
+	assert.Nil(fields)
+
+	validate := validator.New()
+	validate.SetTagName("binding")
+
+	fields, ok = FieldErrors(validate.Struct(&validationTestAddress{}))
+	assert.True(ok)
+	assert.Equal([]FieldError{{Field: "Street", Rule: "required", Message: "Street is missing"}}, fields)
+}
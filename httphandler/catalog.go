@@ -0,0 +1,19 @@
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// Catalog publishes error code catalog entries as JSON, httperr.DefaultCatalog is used when catalog is nil
+func Catalog(catalog *httperr.Catalog) gin.HandlerFunc {
+	if catalog == nil {
+		catalog = httperr.DefaultCatalog
+	}
+
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, catalog.Entries())
+	}
+}
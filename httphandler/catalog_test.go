@@ -0,0 +1,43 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+const catalogTestURL = "/errors"
+
+func TestCatalog(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	catalog := httperr.NewCatalog()
+	assert.NoError(catalog.Register(
+		httperr.CatalogEntry{Code: "USER_NOT_FOUND", Status: http.StatusNotFound, Message: "user {id} not found"},
+		httperr.CatalogEntry{Code: "OUT_OF_CREDIT", Status: http.StatusForbidden, Message: "out of credit", DocURL: "https://example.com/errors/credit"},
+	))
+
+	router := gin.New()
+	router.Handle(http.MethodGet, catalogTestURL, Catalog(catalog))
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, catalogTestURL))
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	defer res.Body.Close()
+
+	data := []httperr.CatalogEntry{}
+	assert.NoError(json.NewDecoder(res.Body).Decode(&data))
+	assert.Equal(catalog.Entries(), data)
+	assert.Equal("OUT_OF_CREDIT", data[0].Code)
+	assert.Equal("https://example.com/errors/credit", data[0].DocURL)
+}
@@ -0,0 +1,35 @@
+package httphandler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/protsack-stephan/gin-toolkit/httpmod"
+)
+
+// OpenAPI serves openapi document as JSON or as YAML when "?format=yaml" or yaml "Accept" header is provided
+func OpenAPI(doc *httpmod.OpenAPI) gin.HandlerFunc {
+	json, errJSON := doc.JSON()
+	yaml, errYAML := doc.YAML()
+
+	return func(c *gin.Context) {
+		if c.Query("format") == "yaml" || strings.Contains(c.GetHeader("Accept"), "yaml") {
+			if errYAML != nil {
+				httperr.InternalServerError(c, errYAML.Error())
+				return
+			}
+
+			c.Data(http.StatusOK, "application/yaml", yaml)
+			return
+		}
+
+		if errJSON != nil {
+			httperr.InternalServerError(c, errJSON.Error())
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json; charset=utf-8", json)
+	}
+}
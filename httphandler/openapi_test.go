@@ -0,0 +1,75 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmod"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const openAPITestURL = "/openapi"
+
+func openAPITestServer(doc *httpmod.OpenAPI) http.Handler {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Handle(http.MethodGet, openAPITestURL, OpenAPI(doc))
+
+	return router
+}
+
+func TestOpenAPI(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := httpmod.NewOpenAPI(&httpmod.OpenAPIParams{Title: "test", Version: "1.0.0"}, []func() httpmod.Module{
+		func() httpmod.Module {
+			return httpmod.Module{
+				Path: "test",
+				Routes: []httpmod.Route{
+					{
+						Path:    "/hello",
+						Method:  http.MethodGet,
+						Handler: func(c *gin.Context) {},
+					},
+				},
+			}
+		},
+	})
+	assert.NoError(err)
+
+	srv := httptest.NewServer(openAPITestServer(doc))
+	defer srv.Close()
+
+	t.Run("json", func(t *testing.T) {
+		res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, openAPITestURL))
+		assert.NoError(err)
+		assert.Equal(http.StatusOK, res.StatusCode)
+		defer res.Body.Close()
+
+		data := new(httpmod.OpenAPI)
+		assert.NoError(json.NewDecoder(res.Body).Decode(data))
+		assert.Equal("test", data.Info.Title)
+		assert.Contains(data.Paths, "/test/hello")
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		res, err := http.Get(fmt.Sprintf("%s%s?format=yaml", srv.URL, openAPITestURL))
+		assert.NoError(err)
+		assert.Equal(http.StatusOK, res.StatusCode)
+		assert.Equal("application/yaml", res.Header.Get("Content-Type"))
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		assert.NoError(err)
+
+		data := map[string]interface{}{}
+		assert.NoError(yaml.Unmarshal(body, &data))
+		assert.Equal(httpmod.OpenAPIVersion, data["openapi"])
+	})
+}
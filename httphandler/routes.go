@@ -0,0 +1,17 @@
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmod"
+)
+
+// Routes lists routes created by httpmod.Init with their middleware and metadata
+func Routes(modules []func() httpmod.Module) gin.HandlerFunc {
+	table := httpmod.Table(modules)
+
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, table)
+	}
+}
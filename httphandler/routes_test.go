@@ -0,0 +1,59 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmod"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+	"github.com/stretchr/testify/assert"
+)
+
+const routesTestURL = "/routes"
+
+func routesTestServer(modules []func() httpmod.Module) http.Handler {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Handle(http.MethodGet, routesTestURL, Routes(modules))
+
+	return router
+}
+
+func TestRoutes(t *testing.T) {
+	assert := assert.New(t)
+	srv := httptest.NewServer(routesTestServer([]func() httpmod.Module{
+		func() httpmod.Module {
+			return httpmod.Module{
+				Path:       "test",
+				Middleware: []gin.HandlerFunc{httpmw.CORS()},
+				Routes: []httpmod.Route{
+					{
+						Path:    "/hello",
+						Method:  http.MethodGet,
+						Handler: func(c *gin.Context) {},
+						Meta:    &httpmod.Meta{Summary: "hello"},
+					},
+				},
+			}
+		},
+	}))
+	defer srv.Close()
+
+	res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, routesTestURL))
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	defer res.Body.Close()
+
+	data := []httpmod.RouteInfo{}
+	assert.NoError(json.NewDecoder(res.Body).Decode(&data))
+	assert.Len(data, 1)
+	assert.Equal("/test/hello", data[0].Path)
+	assert.Equal(http.MethodGet, data[0].Method)
+	assert.Equal([]string{"httpmw.CORS"}, data[0].Middleware)
+	assert.Equal("hello", data[0].Meta.Summary)
+}
@@ -2,43 +2,110 @@ package httphandler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-// StatusResponse health check response
+// DefaultStatusTimeout time limit of every status check unless other is provided
+const DefaultStatusTimeout = time.Second * 5
+
+// ErrStatusTimeout status check did not finish within the timeout
+var ErrStatusTimeout = errors.New("status check timed out")
+
+// StatusResponse health check response, Latency is duration of each check in milliseconds
 type StatusResponse struct {
-	Uptime int               `json:"uptime"`
-	Online map[string]bool   `json:"online"`
-	Errors map[string]string `json:"errors"`
+	Uptime  int               `json:"uptime"`
+	Online  map[string]bool   `json:"online"`
+	Errors  map[string]string `json:"errors"`
+	Latency map[string]int64  `json:"latency"`
 }
 
 // StatusCheck check status of the service
 type StatusCheck func(ctx context.Context) error
 
-// Status health check API endpoint
-func Status(services map[string]StatusCheck) gin.HandlerFunc {
+type statusResult struct {
+	name    string
+	err     error
+	latency time.Duration
+}
+
+// Status health check API endpoint, checks run concurrently and each of them is limited by timeout
+// (DefaultStatusTimeout if not provided), checks that don't finish in time are reported with ErrStatusTimeout
+func Status(services map[string]StatusCheck, timeout ...time.Duration) gin.HandlerFunc {
 	startup := time.Now().UTC()
+	limit := DefaultStatusTimeout
+
+	if len(timeout) > 0 && timeout[0] > 0 {
+		limit = timeout[0]
+	}
 
 	return func(c *gin.Context) {
 		res := StatusResponse{
-			Uptime: int(time.Since(startup).Seconds()),
-			Errors: map[string]string{},
-			Online: map[string]bool{},
+			Uptime:  int(time.Since(startup).Seconds()),
+			Errors:  map[string]string{},
+			Online:  map[string]bool{},
+			Latency: map[string]int64{},
 		}
 
+		results := make(chan statusResult, len(services))
+		wg := sync.WaitGroup{}
+
 		for name, ping := range services {
-			err := ping(c)
+			wg.Add(1)
+
+			go func(name string, ping StatusCheck) {
+				defer wg.Done()
+				results <- check(c.Request.Context(), name, ping, limit)
+			}(name, ping)
+		}
 
-			if err != nil {
-				res.Errors[name] = err.Error()
+		wg.Wait()
+		close(results)
+
+		for result := range results {
+			if result.err != nil {
+				res.Errors[result.name] = result.err.Error()
 			}
 
-			res.Online[name] = err == nil
+			res.Online[result.name] = result.err == nil
+			res.Latency[result.name] = result.latency.Milliseconds()
 		}
 
 		c.JSON(http.StatusOK, res)
 	}
 }
+
+// check runs status check with timeout, does not wait for checks ignoring context cancellation,
+// ErrStatusTimeout is reported only when the timeout is exceeded, not when the request is canceled
+func check(ctx context.Context, name string, ping StatusCheck, timeout time.Duration) statusResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+
+	go func() {
+		done <- ping(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ErrStatusTimeout
+		}
+
+		return statusResult{name, err, time.Since(start)}
+	case <-ctx.Done():
+		err := ctx.Err()
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = ErrStatusTimeout
+		}
+
+		return statusResult{name, err, time.Since(start)}
+	}
+}
@@ -17,12 +17,13 @@ import (
 const statusTestURL = "/status"
 const statusTestCache = "redis"
 const statusTestDB = "db"
+const statusTestQueue = "queue"
 
-func statusTestServer(services map[string]StatusCheck) http.Handler {
+func statusTestServer(services map[string]StatusCheck, timeout ...time.Duration) http.Handler {
 	gin.SetMode(gin.TestMode)
 
 	router := gin.New()
-	router.Handle(http.MethodGet, statusTestURL, Status(services))
+	router.Handle(http.MethodGet, statusTestURL, Status(services, timeout...))
 
 	return router
 }
@@ -57,3 +58,60 @@ func TestStatus(t *testing.T) {
 	assert.Equal(data.Online[statusTestDB], true)
 	assert.Equal(data.Online[statusTestCache], false)
 }
+
+func TestStatusTimeout(t *testing.T) {
+	assert := assert.New(t)
+	timeout := time.Millisecond * 100
+	srv := httptest.NewServer(statusTestServer(map[string]StatusCheck{
+		statusTestDB: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		statusTestCache: func(_ context.Context) error {
+			time.Sleep(time.Second * 1)
+			return nil
+		},
+		statusTestQueue: func(_ context.Context) error {
+			return nil
+		},
+	}, timeout))
+	defer srv.Close()
+
+	start := time.Now()
+	res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, statusTestURL))
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Less(int64(time.Since(start)), int64(time.Second*1))
+	defer res.Body.Close()
+
+	data := new(StatusResponse)
+	assert.NoError(json.NewDecoder(res.Body).Decode(data))
+
+	assert.Equal(ErrStatusTimeout.Error(), data.Errors[statusTestDB])
+	assert.Equal(ErrStatusTimeout.Error(), data.Errors[statusTestCache])
+	assert.False(data.Online[statusTestDB])
+	assert.False(data.Online[statusTestCache])
+	assert.True(data.Online[statusTestQueue])
+	assert.GreaterOrEqual(data.Latency[statusTestDB], timeout.Milliseconds())
+	assert.Less(data.Latency[statusTestQueue], timeout.Milliseconds())
+}
+
+func TestStatusCanceled(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, statusTestURL, nil).WithContext(ctx)
+	statusTestServer(map[string]StatusCheck{
+		statusTestDB: func(_ context.Context) error {
+			time.Sleep(time.Second * 1)
+			return nil
+		},
+	}).ServeHTTP(res, req)
+
+	data := new(StatusResponse)
+	assert.NoError(json.NewDecoder(res.Body).Decode(data))
+	assert.Equal(context.Canceled.Error(), data.Errors[statusTestDB])
+	assert.False(data.Online[statusTestDB])
+}
@@ -0,0 +1,378 @@
+package httpmod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+	"gopkg.in/yaml.v3"
+)
+
+// MiddlewareFactory creates middleware from config arguments
+type MiddlewareFactory func(args ...string) (gin.HandlerFunc, error)
+
+// Registry named handlers and middleware factories referenced by module config
+type Registry struct {
+	Handlers   map[string]gin.HandlerFunc
+	Middleware map[string]MiddlewareFactory
+}
+
+// NewRegistry creates registry with built-in middleware:
+// * "cors" - httpmw.CORS
+// * "limit" - httpmw.Limit, arguments are limit and optional IP ranges
+// * "basic_auth" - httpmw.BasicAuth, argument is accounts list
+// * "ip_basic_auth" - httpmw.IPBasicAuth, arguments are IP ranges and accounts list
+func NewRegistry() *Registry {
+	return &Registry{
+		Handlers: map[string]gin.HandlerFunc{},
+		Middleware: map[string]MiddlewareFactory{
+			"cors": func(args ...string) (gin.HandlerFunc, error) {
+				return httpmw.CORS(), nil
+			},
+			"limit": func(args ...string) (gin.HandlerFunc, error) {
+				if len(args) < 1 {
+					return nil, fmt.Errorf("limit requires at least 1 argument, got %d", len(args))
+				}
+
+				limit, err := strconv.Atoi(args[0])
+
+				if err != nil {
+					return nil, fmt.Errorf("limit should be a number: %s", args[0])
+				}
+
+				return httpmw.Limit(limit, args[1:]...), nil
+			},
+			"basic_auth": func(args ...string) (gin.HandlerFunc, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("basic_auth requires 1 argument, got %d", len(args))
+				}
+
+				return httpmw.BasicAuth(args[0]), nil
+			},
+			"ip_basic_auth": func(args ...string) (gin.HandlerFunc, error) {
+				if len(args) != 2 {
+					return nil, fmt.Errorf("ip_basic_auth requires 2 arguments, got %d", len(args))
+				}
+
+				return httpmw.IPBasicAuth(args[0], args[1]), nil
+			},
+		},
+	}
+}
+
+// ConfigError invalid module config with position of the offending node
+type ConfigError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error formats error as "file:line:column: message", column and line are omitted when unknown
+func (e *ConfigError) Error() string {
+	file := e.File
+
+	if len(file) <= 0 {
+		file = "config"
+	}
+
+	if e.Line <= 0 {
+		return fmt.Sprintf("%s: %s", file, e.Message)
+	}
+
+	if e.Column <= 0 {
+		return fmt.Sprintf("%s:%d: %s", file, e.Line, e.Message)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", file, e.Line, e.Column, e.Message)
+}
+
+// LoadConfigFile reads YAML or JSON module config from file, see LoadConfig
+func LoadConfigFile(name string, reg *Registry) ([]func() Module, error) {
+	data, err := ioutil.ReadFile(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := LoadConfig(data, reg)
+
+	if cerr, ok := err.(*ConfigError); ok {
+		cerr.File = name
+	}
+
+	return modules, err
+}
+
+// LoadConfig builds modules from YAML or JSON config, handlers and middleware are resolved by name in the registry:
+//
+//	modules:
+//	  - path: admin
+//	    version: v1
+//	    middleware:
+//	      - cors
+//	      - name: basic_auth
+//	        args: ["user:pass"]
+//	    routes:
+//	      - method: GET
+//	        path: /users
+//	        handler: users.list
+//	        disabled: true
+//	    modules: []
+func LoadConfig(data []byte, reg *Registry) ([]func() Module, error) {
+	doc := new(yaml.Node)
+
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, yamlConfigError(err)
+	}
+
+	if len(doc.Content) <= 0 {
+		return nil, ErrEmptyModules
+	}
+
+	modules := []Module{}
+
+	err := mapping(doc.Content[0], func(key *yaml.Node, value *yaml.Node) (err error) {
+		if key.Value != "modules" {
+			return unknownField(key)
+		}
+
+		modules, err = configModules(value, reg)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(modules) <= 0 {
+		return nil, ErrEmptyModules
+	}
+
+	getters := make([]func() Module, 0, len(modules))
+
+	for _, module := range modules {
+		module := module
+		getters = append(getters, func() Module {
+			return module
+		})
+	}
+
+	return getters, nil
+}
+
+func configModules(node *yaml.Node, reg *Registry) ([]Module, error) {
+	modules := []Module{}
+
+	err := sequence(node, func(item *yaml.Node) error {
+		module, disabled := Module{}, false
+
+		err := mapping(item, func(key *yaml.Node, value *yaml.Node) (err error) {
+			switch key.Value {
+			case "path":
+				return scalar(value, &module.Path)
+			case "version":
+				return scalar(value, &module.Version)
+			case "disabled":
+				return scalar(value, &disabled)
+			case "middleware":
+				module.Middleware, err = configMiddleware(value, reg)
+			case "routes":
+				module.Routes, err = configRoutes(value, reg)
+			case "modules":
+				module.Modules, err = configModules(value, reg)
+			default:
+				return unknownField(key)
+			}
+
+			return err
+		})
+
+		if err == nil && !disabled {
+			modules = append(modules, module)
+		}
+
+		return err
+	})
+
+	return modules, err
+}
+
+func configRoutes(node *yaml.Node, reg *Registry) ([]Route, error) {
+	routes := []Route{}
+
+	err := sequence(node, func(item *yaml.Node) error {
+		route, disabled, handler := Route{}, false, ""
+
+		err := mapping(item, func(key *yaml.Node, value *yaml.Node) (err error) {
+			switch key.Value {
+			case "method":
+				if err := scalar(value, &route.Method); err != nil {
+					return err
+				}
+
+				route.Method = strings.ToUpper(route.Method)
+			case "path":
+				return scalar(value, &route.Path)
+			case "handler":
+				if err := scalar(value, &handler); err != nil {
+					return err
+				}
+
+				if route.Handler = reg.Handlers[handler]; route.Handler == nil {
+					return newConfigError(value, fmt.Sprintf("unknown handler %q", handler))
+				}
+			case "disabled":
+				return scalar(value, &disabled)
+			case "middleware":
+				route.Middleware, err = configMiddleware(value, reg)
+			default:
+				return unknownField(key)
+			}
+
+			return err
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if len(route.Method) <= 0 {
+			return newConfigError(item, "route method is required")
+		}
+
+		if route.Handler == nil {
+			return newConfigError(item, "route handler is required")
+		}
+
+		if !disabled {
+			routes = append(routes, route)
+		}
+
+		return nil
+	})
+
+	return routes, err
+}
+
+func configMiddleware(node *yaml.Node, reg *Registry) ([]gin.HandlerFunc, error) {
+	middleware := []gin.HandlerFunc{}
+
+	err := sequence(node, func(item *yaml.Node) error {
+		name, args := "", []string{}
+
+		if item.Kind == yaml.ScalarNode {
+			if err := scalar(item, &name); err != nil {
+				return err
+			}
+		} else {
+			err := mapping(item, func(key *yaml.Node, value *yaml.Node) error {
+				switch key.Value {
+				case "name":
+					return scalar(value, &name)
+				case "args":
+					return sequence(value, func(arg *yaml.Node) error {
+						val := ""
+
+						if err := scalar(arg, &val); err != nil {
+							return err
+						}
+
+						args = append(args, val)
+						return nil
+					})
+				}
+
+				return unknownField(key)
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		factory, ok := reg.Middleware[name]
+
+		if !ok {
+			return newConfigError(item, fmt.Sprintf("unknown middleware %q", name))
+		}
+
+		handler, err := factory(args...)
+
+		if err != nil {
+			return newConfigError(item, err.Error())
+		}
+
+		middleware = append(middleware, handler)
+		return nil
+	})
+
+	return middleware, err
+}
+
+func mapping(node *yaml.Node, fn func(key *yaml.Node, value *yaml.Node) error) error {
+	if node.Kind != yaml.MappingNode {
+		return newConfigError(node, "expected a mapping")
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := fn(node.Content[i], node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sequence(node *yaml.Node, fn func(item *yaml.Node) error) error {
+	if node.Kind != yaml.SequenceNode {
+		return newConfigError(node, "expected a list")
+	}
+
+	for _, item := range node.Content {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scalar(node *yaml.Node, out interface{}) error {
+	if node.Kind != yaml.ScalarNode {
+		return newConfigError(node, "expected a value")
+	}
+
+	if err := node.Decode(out); err != nil {
+		return newConfigError(node, fmt.Sprintf("invalid value %q", node.Value))
+	}
+
+	return nil
+}
+
+func unknownField(key *yaml.Node) error {
+	return newConfigError(key, fmt.Sprintf("unknown field %q", key.Value))
+}
+
+func newConfigError(node *yaml.Node, message string) *ConfigError {
+	return &ConfigError{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: message,
+	}
+}
+
+// yamlConfigError converts "yaml: line 2: message" error, line is left unknown when yaml doesn't report it
+func yamlConfigError(err error) error {
+	cerr := &ConfigError{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+	_, scanErr := fmt.Sscanf(cerr.Message, "line %d:", &cerr.Line)
+
+	if scanErr == nil {
+		cerr.Message = strings.TrimSpace(strings.SplitN(cerr.Message, ":", 2)[1])
+	}
+
+	return cerr
+}
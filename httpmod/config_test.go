@@ -0,0 +1,173 @@
+package httpmod
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const configTestYAML = `
+modules:
+  - path: admin
+    version: v1
+    middleware:
+      - cors
+      - name: basic_auth
+        args: ["admin:secret"]
+    routes:
+      - method: get
+        path: /users
+        handler: users.list
+      - method: DELETE
+        path: /users/:id
+        handler: users.delete
+        disabled: true
+  - path: legacy
+    disabled: true
+    routes:
+      - method: GET
+        path: /
+        handler: users.list
+`
+
+const configTestJSON = `{
+  "modules": [
+    {
+      "path": "public",
+      "routes": [
+        {"method": "GET", "path": "/users", "handler": "users.list", "middleware": [{"name": "limit", "args": ["10"]}]}
+      ]
+    }
+  ]
+}`
+
+func configTestRegistry() *Registry {
+	reg := NewRegistry()
+	reg.Handlers["users.list"] = func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+	reg.Handlers["users.delete"] = func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	}
+
+	return reg
+}
+
+func TestLoadConfig(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	t.Run("yaml", func(t *testing.T) {
+		modules, err := LoadConfig([]byte(configTestYAML), configTestRegistry())
+		assert.NoError(err)
+		assert.Len(modules, 1)
+
+		module := modules[0]()
+		assert.Equal("v1", module.Version)
+		assert.Len(module.Middleware, 2)
+		assert.Len(module.Routes, 1)
+		assert.Equal(http.MethodGet, module.Routes[0].Method)
+
+		router := gin.New()
+		assert.NoError(Init(router, modules))
+
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+
+		res, err := http.Get(fmt.Sprintf("%s/v1/admin/users", srv.URL))
+		assert.NoError(err)
+		assert.Equal(http.StatusUnauthorized, res.StatusCode)
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/admin/users", srv.URL), nil)
+		assert.NoError(err)
+		req.SetBasicAuth("admin", "secret")
+
+		res, err = http.DefaultClient.Do(req)
+		assert.NoError(err)
+		assert.Equal(http.StatusOK, res.StatusCode)
+		assert.Equal("*", res.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		modules, err := LoadConfig([]byte(configTestJSON), configTestRegistry())
+		assert.NoError(err)
+		assert.Len(modules, 1)
+		assert.Equal("public", modules[0]().Path)
+		assert.Len(modules[0]().Routes[0].Middleware, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, test := range []struct {
+			Config string
+			Error  string
+		}{
+			{
+				"modules:\n  - path: test\n    routes:\n      - method: GET\n        handler: missing\n",
+				"config:5:18: unknown handler \"missing\"",
+			},
+			{
+				"modules:\n  - path: test\n    middleware:\n      - unknown\n",
+				"config:4:9: unknown middleware \"unknown\"",
+			},
+			{
+				"modules:\n  - path: test\n    middleware:\n      - name: limit\n        args: [ten]\n",
+				"config:4:9: limit should be a number: ten",
+			},
+			{
+				"modules:\n  - path: test\n    prefix: /api\n",
+				"config:3:5: unknown field \"prefix\"",
+			},
+			{
+				"modules:\n  - path: test\n    routes:\n      - path: /\n        handler: users.list\n",
+				"config:4:9: route method is required",
+			},
+			{
+				"modules:\n  - path: test\n    routes: {}\n",
+				"config:3:13: expected a list",
+			},
+			{
+				"modules:\n  - path: [\n",
+				"config:2: did not find expected node content",
+			},
+			{
+				"{\"modules\": [}",
+				"config: did not find expected node content",
+			},
+			{
+				"0: [:!00 \xef",
+				"",
+			},
+		} {
+			_, err := LoadConfig([]byte(test.Config), configTestRegistry())
+			cerr := new(ConfigError)
+			assert.True(errors.As(err, &cerr), test.Config)
+
+			if len(test.Error) > 0 {
+				assert.EqualError(err, test.Error)
+			}
+		}
+
+		_, err := LoadConfig([]byte(""), configTestRegistry())
+		assert.Equal(ErrEmptyModules, err)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "modules-*.yaml")
+		assert.NoError(err)
+		defer os.Remove(file.Name())
+
+		_, err = file.WriteString("modules:\n  - path: test\n    middleware: [unknown]\n")
+		assert.NoError(err)
+		assert.NoError(file.Close())
+
+		_, err = LoadConfigFile(file.Name(), configTestRegistry())
+		assert.EqualError(err, fmt.Sprintf("%s:3:18: unknown middleware \"unknown\"", file.Name()))
+	})
+}
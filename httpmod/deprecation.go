@@ -0,0 +1,44 @@
+package httpmod
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+)
+
+// Deprecation marks module or route as deprecated
+// Date is the moment of deprecation (nil means deprecated without date),
+// Sunset is the moment the route stops working (RFC 8594), Link points to migration docs
+type Deprecation struct {
+	Date   *time.Time `json:"date,omitempty"`
+	Sunset *time.Time `json:"sunset,omitempty"`
+	Link   string     `json:"link,omitempty"`
+}
+
+// deprecated sets Deprecation, Sunset and Link headers and marks request
+// as deprecated under httpmw.DeprecatedKey for the logging middleware
+func deprecated(d *Deprecation) gin.HandlerFunc {
+	deprecation := "true"
+
+	if d.Date != nil {
+		deprecation = fmt.Sprintf("@%d", d.Date.Unix())
+	}
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+
+		if d.Sunset != nil {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if len(d.Link) > 0 {
+			c.Writer.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", d.Link))
+		}
+
+		c.Set(httpmw.DeprecatedKey, true)
+		c.Next()
+	}
+}
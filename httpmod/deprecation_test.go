@@ -0,0 +1,84 @@
+package httpmod
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecation(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	date := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	deprecated := map[string]bool{}
+
+	handler := func(c *gin.Context) {
+		deprecated[c.FullPath()] = c.GetBool(httpmw.DeprecatedKey)
+		c.Status(http.StatusOK)
+	}
+
+	err := Init(router, []func() Module{
+		func() Module {
+			return Module{
+				Version: "v1",
+				Path:    "users",
+				Deprecation: &Deprecation{
+					Date:   &date,
+					Sunset: &sunset,
+					Link:   "https://example.com/migrate",
+				},
+				Routes: []Route{
+					{Path: "/", Method: http.MethodGet, Handler: handler},
+				},
+			}
+		},
+		func() Module {
+			return Module{
+				Version: "v2",
+				Path:    "users",
+				Routes: []Route{
+					{Path: "/", Method: http.MethodGet, Handler: handler},
+					{Path: "/old", Method: http.MethodGet, Handler: handler, Deprecation: new(Deprecation)},
+				},
+			}
+		},
+	})
+	assert.NoError(err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	res, err := http.Get(fmt.Sprintf("%s/v1/users/", srv.URL))
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(fmt.Sprintf("@%d", date.Unix()), res.Header.Get("Deprecation"))
+	assert.Equal("Mon, 01 Jun 2026 00:00:00 GMT", res.Header.Get("Sunset"))
+	assert.Equal("<https://example.com/migrate>; rel=\"deprecation\"", res.Header.Get("Link"))
+	assert.True(deprecated["/v1/users/"])
+
+	res, err = http.Get(fmt.Sprintf("%s/v2/users/", srv.URL))
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Empty(res.Header.Get("Deprecation"))
+	assert.False(deprecated["/v2/users/"])
+
+	res, err = http.Get(fmt.Sprintf("%s/v2/users/old", srv.URL))
+	assert.NoError(err)
+	assert.Equal("true", res.Header.Get("Deprecation"))
+	assert.Empty(res.Header.Get("Sunset"))
+	assert.True(deprecated["/v2/users/old"])
+
+	data, err := json.Marshal(&Deprecation{Sunset: &sunset})
+	assert.NoError(err)
+	assert.JSONEq(`{"sunset":"2026-06-01T00:00:00Z"}`, string(data))
+}
@@ -0,0 +1,75 @@
+package httpmod
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// NewDispatcher creates dispatcher with no routes, newRouter is called for every reload
+// to create a fresh engine with global middleware, defaults to gin.New
+func NewDispatcher(newRouter func() *gin.Engine) *Dispatcher {
+	if newRouter == nil {
+		newRouter = gin.New
+	}
+
+	notLoaded := gin.New()
+	notLoaded.NoRoute(func(c *gin.Context) {
+		httperr.ServiceUnavailable(c, "routes are not loaded")
+	})
+
+	return &Dispatcher{
+		newRouter: newRouter,
+		notLoaded: notLoaded,
+	}
+}
+
+// Dispatcher http.Handler which route tree can be replaced while serving,
+// in-flight requests finish on the tree they started on
+type Dispatcher struct {
+	newRouter func() *gin.Engine
+	notLoaded *gin.Engine
+	router    atomic.Value
+	mut       sync.Mutex
+}
+
+// Reload builds route tree from modules and swaps it in,
+// on validation or registration error live routes are left untouched
+func (d *Dispatcher) Reload(modules []func() Module) error {
+	if len(modules) <= 0 {
+		return ErrEmptyModules
+	}
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	router := d.newRouter()
+
+	if err := initModules(router, getModules(modules), false); err != nil {
+		return err
+	}
+
+	d.router.Store(router)
+	return nil
+}
+
+// Router currently served engine, nil before the first successful reload
+func (d *Dispatcher) Router() *gin.Engine {
+	router, _ := d.router.Load().(*gin.Engine)
+	return router
+}
+
+// ServeHTTP serves request with the current route tree, responds with 503 until routes are loaded
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router := d.Router()
+
+	if router == nil {
+		d.notLoaded.ServeHTTP(w, r)
+		return
+	}
+
+	router.ServeHTTP(w, r)
+}
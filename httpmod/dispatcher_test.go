@@ -0,0 +1,174 @@
+package httpmod
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func dispatcherModules(body string, started chan struct{}, release chan struct{}) []func() Module {
+	return []func() Module{
+		func() Module {
+			return Module{
+				Path: "api",
+				Routes: []Route{
+					{
+						Path:   "/version",
+						Method: http.MethodGet,
+						Handler: func(c *gin.Context) {
+							c.String(http.StatusOK, body)
+						},
+					},
+					{
+						Path:   "/slow",
+						Method: http.MethodGet,
+						Handler: func(c *gin.Context) {
+							started <- struct{}{}
+							<-release
+							c.String(http.StatusOK, body)
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+func dispatcherGet(srv *httptest.Server, path string) (int, string, error) {
+	res, err := http.Get(srv.URL + path)
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	return res.StatusCode, string(body), err
+}
+
+func TestDispatcher(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	started, release := make(chan struct{}), make(chan struct{})
+
+	dispatcher := NewDispatcher(nil)
+	srv := httptest.NewServer(dispatcher)
+	defer srv.Close()
+
+	status, _, err := dispatcherGet(srv, "/api/version")
+	assert.NoError(err)
+	assert.Equal(http.StatusServiceUnavailable, status)
+	assert.Nil(dispatcher.Router())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+	req.Header.Set("Accept", "text/plain")
+	res := httptest.NewRecorder()
+	NewDispatcher(nil).ServeHTTP(res, req)
+	assert.Equal(http.StatusServiceUnavailable, res.Code)
+	assert.Equal("503 Service Unavailable: routes are not loaded\n", res.Body.String())
+	assert.Equal(ErrEmptyModules, dispatcher.Reload([]func() Module{}))
+
+	assert.NoError(dispatcher.Reload(dispatcherModules("v1", started, release)))
+
+	status, body, err := dispatcherGet(srv, "/api/version")
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("v1", body)
+
+	slow := make(chan string)
+
+	go func() {
+		_, body, _ := dispatcherGet(srv, "/api/slow")
+		slow <- body
+	}()
+
+	<-started
+	assert.NoError(dispatcher.Reload(dispatcherModules("v2", started, release)))
+	close(release)
+	assert.Equal("v1", <-slow)
+
+	status, body, err = dispatcherGet(srv, "/api/version")
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("v2", body)
+
+	err = dispatcher.Reload([]func() Module{
+		func() Module {
+			return Module{
+				Routes: []Route{
+					{Path: "/:id", Method: http.MethodGet, Handler: func(c *gin.Context) {}},
+					{Path: "/:name", Method: http.MethodGet, Handler: func(c *gin.Context) {}},
+				},
+			}
+		},
+	})
+	assert.True(errors.Is(err, ErrRouteConflict))
+
+	status, body, err = dispatcherGet(srv, "/api/version")
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("v2", body)
+}
+
+func TestDispatcherUnderLoad(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	dispatcher := NewDispatcher(func() *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Header("X-Router", "fresh")
+		})
+		return router
+	})
+	assert.NoError(dispatcher.Reload(dispatcherModules("v1", nil, nil)))
+
+	srv := httptest.NewServer(dispatcher)
+	defer srv.Close()
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 100)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 10; j++ {
+				status, body, err := dispatcherGet(srv, "/api/version")
+
+				if err == nil && (status != http.StatusOK || (body != "v1" && body != "v2")) {
+					err = errors.New("unexpected response: " + body)
+				}
+
+				if err != nil {
+					errs <- err
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			dispatcher.Reload(dispatcherModules("v2", nil, nil))
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(err)
+	}
+
+	res, err := http.Get(srv.URL + "/api/version")
+	assert.NoError(err)
+	res.Body.Close()
+	assert.Equal("fresh", res.Header.Get("X-Router"))
+}
@@ -0,0 +1,29 @@
+package httpmod
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// StatusCoder error that carries http status code
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// ErrorHandler maps error returned by route HandlerE to http response
+type ErrorHandler func(c *gin.Context, err error)
+
+// DefaultErrorHandler responds with httperr error using status of the error (if provided) or 500,
+// *httperr.Error is responded as is, see httperr.Render
+func DefaultErrorHandler(c *gin.Context, err error) {
+	httperr.Render(c, err)
+}
+
+func handlerE(handler func(c *gin.Context) error, onError ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := handler(c); err != nil {
+			onError(c, err)
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package httpmod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+type errorTestStatusErr struct {
+	status int
+}
+
+func (e *errorTestStatusErr) Error() string {
+	return http.StatusText(e.status)
+}
+
+func (e *errorTestStatusErr) StatusCode() int {
+	return e.status
+}
+
+func errorTestRoute(path string, err error) Route {
+	return Route{
+		Path:   path,
+		Method: http.MethodGet,
+		HandlerE: func(c *gin.Context) error {
+			if err != nil {
+				return err
+			}
+
+			c.Status(http.StatusNoContent)
+			return nil
+		},
+	}
+}
+
+func TestHandlerE(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	errInternal := errors.New("db is down")
+	errNotFound := fmt.Errorf("user lookup: %w", &errorTestStatusErr{http.StatusNotFound})
+
+	err := Init(router, []func() Module{
+		func() Module {
+			return Module{
+				Path: "errors",
+				Routes: []Route{
+					errorTestRoute("/ok", nil),
+					errorTestRoute("/internal", errInternal),
+					errorTestRoute("/status", errNotFound),
+					errorTestRoute("/httperr", fmt.Errorf("handler: %w", httperr.Wrap(http.StatusConflict, errInternal, "user exists"))),
+				},
+				Modules: []Module{
+					{
+						Path: "custom",
+						ErrorHandler: func(c *gin.Context, err error) {
+							httperr.BadRequest(c, err.Error())
+						},
+						Routes: []Route{
+							errorTestRoute("/internal", errInternal),
+						},
+					},
+				},
+			}
+		},
+	})
+	assert.NoError(err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for _, test := range []struct {
+		URL    string
+		Status int
+		Error  string
+	}{
+		{"/errors/ok", http.StatusNoContent, ""},
+		{"/errors/internal", http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)},
+		{"/errors/status", http.StatusNotFound, errNotFound.Error()},
+		{"/errors/httperr", http.StatusConflict, "user exists"},
+		{"/errors/custom/internal", http.StatusBadRequest, errInternal.Error()},
+	} {
+		res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, test.URL))
+		assert.NoError(err)
+		defer res.Body.Close()
+		assert.Equal(test.Status, res.StatusCode)
+
+		if len(test.Error) > 0 {
+			data := new(httperr.Error)
+			assert.NoError(json.NewDecoder(res.Body).Decode(data))
+			assert.Equal(test.Status, data.Status)
+			assert.Equal(test.Error, data.Message)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package httpmod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+)
+
+// ErrNoFlagProvider route or module has a flag but no flag provider was set
+var ErrNoFlagProvider = errors.New("no flag provider")
+
+// FlagState feature flag state
+// Percentage (1-99) enables flag for a stable share of users (by username or client IP), 0 or 100 means everyone
+// Groups restricts flag to the cognito users in any of the groups
+type FlagState struct {
+	Enabled    bool     `json:"enabled"`
+	Percentage int      `json:"percentage,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+}
+
+// FlagProvider source of feature flags, unknown flags should be returned as nil state and are treated as disabled
+type FlagProvider interface {
+	Flag(ctx context.Context, name string) (*FlagState, error)
+}
+
+// NewMemoryFlags creates in-memory flag provider
+func NewMemoryFlags() *MemoryFlags {
+	return &MemoryFlags{
+		flags: map[string]*FlagState{},
+	}
+}
+
+// MemoryFlags in-memory flag provider, safe for concurrent use
+type MemoryFlags struct {
+	mut   sync.RWMutex
+	flags map[string]*FlagState
+}
+
+// Set updates flag state
+func (mf *MemoryFlags) Set(name string, state *FlagState) {
+	mf.mut.Lock()
+	defer mf.mut.Unlock()
+
+	mf.flags[name] = state
+}
+
+// Flag get flag state
+func (mf *MemoryFlags) Flag(_ context.Context, name string) (*FlagState, error) {
+	mf.mut.RLock()
+	defer mf.mut.RUnlock()
+
+	return mf.flags[name], nil
+}
+
+// NewRedisFlags creates redis flag provider, flags are stored as JSON under "flag:<name>" keys
+func NewRedisFlags(cmdable redis.Cmdable) *RedisFlags {
+	return &RedisFlags{
+		cmdable: cmdable,
+	}
+}
+
+// RedisFlags redis flag provider, allows to share flags between instances
+type RedisFlags struct {
+	cmdable redis.Cmdable
+}
+
+func (rf *RedisFlags) getKey(name string) string {
+	return fmt.Sprintf("flag:%s", name)
+}
+
+// Set updates flag state
+func (rf *RedisFlags) Set(ctx context.Context, name string, state *FlagState) error {
+	data, err := json.Marshal(state)
+
+	if err != nil {
+		return err
+	}
+
+	return rf.cmdable.Set(ctx, rf.getKey(name), data, 0).Err()
+}
+
+// Flag get flag state
+func (rf *RedisFlags) Flag(ctx context.Context, name string) (*FlagState, error) {
+	data, err := rf.cmdable.Get(ctx, rf.getKey(name)).Bytes()
+
+	if err == redis.Nil {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := new(FlagState)
+	return state, json.Unmarshal(data, state)
+}
+
+// flagged checks that all flags are enabled for the request, otherwise responds with fallback
+func flagged(provider FlagProvider, flags []string, fallback gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range flags {
+			state, err := provider.Flag(c.Request.Context(), name)
+
+			if err != nil {
+				httperr.Respond(c, httperr.Wrap(http.StatusInternalServerError, err))
+				c.Abort()
+				return
+			}
+
+			if !flagEnabled(c, name, state) {
+				fallback(c)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func flagEnabled(c *gin.Context, name string, state *FlagState) bool {
+	if state == nil || !state.Enabled {
+		return false
+	}
+
+	var user *httpmw.CognitoUser
+
+	if model, ok := c.Get("user"); ok {
+		user, _ = model.(*httpmw.CognitoUser)
+	}
+
+	if len(state.Groups) > 0 && (user == nil || !user.IsInGroup(state.Groups...)) {
+		return false
+	}
+
+	if state.Percentage <= 0 || state.Percentage >= 100 {
+		return true
+	}
+
+	id := ""
+
+	if user != nil {
+		id = user.GetUsername()
+	}
+
+	if len(id) <= 0 {
+		id = c.ClientIP()
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(name + ":" + id))
+
+	return int(hash.Sum32()%100) < state.Percentage
+}
+
+func flagNotFound(c *gin.Context) {
+	httperr.NotFound(c)
+}
@@ -0,0 +1,175 @@
+package httpmod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+	"github.com/stretchr/testify/assert"
+)
+
+const flagTestModule = "module"
+const flagTestRoute = "route"
+
+func flagTestServer(flags FlagProvider, response gin.HandlerFunc, user *httpmw.CognitoUser) (*httptest.Server, error) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	err := Init(router, []func() Module{
+		func() Module {
+			return Module{
+				Path:         "flags",
+				Flag:         flagTestModule,
+				Flags:        flags,
+				FlagResponse: response,
+				Middleware: []gin.HandlerFunc{
+					func(c *gin.Context) {
+						if user != nil {
+							c.Set("user", user)
+						}
+					},
+				},
+				Routes: []Route{
+					{
+						Path:   "/",
+						Method: http.MethodGet,
+						Handler: func(c *gin.Context) {
+							c.Status(http.StatusOK)
+						},
+					},
+					{
+						Path:   "/route",
+						Method: http.MethodGet,
+						Flag:   flagTestRoute,
+						Handler: func(c *gin.Context) {
+							c.Status(http.StatusOK)
+						},
+					},
+				},
+			}
+		},
+	})
+
+	return httptest.NewServer(router), err
+}
+
+func flagTestStatus(t *testing.T, srv *httptest.Server, path string) int {
+	res, err := http.Get(fmt.Sprintf("%s%s", srv.URL, path))
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	return res.StatusCode
+}
+
+func TestFlags(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("memory provider", func(t *testing.T) {
+		flags := NewMemoryFlags()
+		srv, err := flagTestServer(flags, nil, nil)
+		assert.NoError(err)
+		defer srv.Close()
+
+		assert.Equal(http.StatusNotFound, flagTestStatus(t, srv, "/flags/"))
+
+		flags.Set(flagTestModule, &FlagState{Enabled: true})
+		assert.Equal(http.StatusOK, flagTestStatus(t, srv, "/flags/"))
+		assert.Equal(http.StatusNotFound, flagTestStatus(t, srv, "/flags/route"))
+
+		flags.Set(flagTestRoute, &FlagState{Enabled: true})
+		assert.Equal(http.StatusOK, flagTestStatus(t, srv, "/flags/route"))
+
+		flags.Set(flagTestModule, &FlagState{Enabled: false})
+		assert.Equal(http.StatusNotFound, flagTestStatus(t, srv, "/flags/route"))
+	})
+
+	t.Run("redis provider", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		assert.NoError(err)
+		defer mr.Close()
+
+		flags := NewRedisFlags(redis.NewClient(&redis.Options{
+			Addr: mr.Addr(),
+		}))
+
+		srv, err := flagTestServer(flags, nil, nil)
+		assert.NoError(err)
+		defer srv.Close()
+
+		assert.Equal(http.StatusNotFound, flagTestStatus(t, srv, "/flags/"))
+
+		assert.NoError(flags.Set(context.Background(), flagTestModule, &FlagState{Enabled: true}))
+		assert.Equal(http.StatusOK, flagTestStatus(t, srv, "/flags/"))
+
+		state, err := flags.Flag(context.Background(), flagTestModule)
+		assert.NoError(err)
+		assert.True(state.Enabled)
+
+		mr.Set("flag:broken", "{")
+		_, err = flags.Flag(context.Background(), "broken")
+		assert.Error(err)
+	})
+
+	t.Run("custom response", func(t *testing.T) {
+		srv, err := flagTestServer(NewMemoryFlags(), func(c *gin.Context) {
+			c.Status(http.StatusServiceUnavailable)
+		}, nil)
+		assert.NoError(err)
+		defer srv.Close()
+
+		assert.Equal(http.StatusServiceUnavailable, flagTestStatus(t, srv, "/flags/"))
+	})
+
+	t.Run("group rollout", func(t *testing.T) {
+		flags := NewMemoryFlags()
+		flags.Set(flagTestModule, &FlagState{Enabled: true, Groups: []string{"beta"}})
+
+		user := new(httpmw.CognitoUser)
+		user.SetUsername("john")
+		user.SetGroups([]string{"admin"})
+
+		srv, err := flagTestServer(flags, nil, user)
+		assert.NoError(err)
+		defer srv.Close()
+
+		assert.Equal(http.StatusNotFound, flagTestStatus(t, srv, "/flags/"))
+
+		user.SetGroups([]string{"beta"})
+		assert.Equal(http.StatusOK, flagTestStatus(t, srv, "/flags/"))
+	})
+
+	t.Run("percentage rollout", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		enabled := 0
+
+		for i := 0; i < 1000; i++ {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			user := new(httpmw.CognitoUser)
+			user.SetUsername(fmt.Sprintf("user-%d", i))
+			c.Set("user", user)
+
+			if flagEnabled(c, flagTestRoute, &FlagState{Enabled: true, Percentage: 30}) {
+				enabled++
+			}
+
+			assert.Equal(
+				flagEnabled(c, flagTestRoute, &FlagState{Enabled: true, Percentage: 30}),
+				flagEnabled(c, flagTestRoute, &FlagState{Enabled: true, Percentage: 30}),
+			)
+		}
+
+		assert.InDelta(300, enabled, 60)
+	})
+
+	t.Run("no provider", func(t *testing.T) {
+		_, err := flagTestServer(nil, nil, nil)
+		assert.True(errors.Is(err, ErrNoFlagProvider))
+	})
+}
@@ -2,6 +2,7 @@ package httpmod
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,24 +10,103 @@ import (
 // ErrEmptyModules provided empty module list
 var ErrEmptyModules = errors.New("empty modules")
 
+// ErrInvalidRoute route has no method or handler, or was rejected by the router
+var ErrInvalidRoute = errors.New("invalid route")
+
 // Init create modules
+// routes are validated before registration, conflicts are returned as *ConflictError
 func Init(router *gin.Engine, modules []func() Module) error {
 	if len(modules) <= 0 {
 		return ErrEmptyModules
 	}
 
-	for _, getter := range modules {
-		module := getter()
-		group := router.Group(module.Path)
+	return initModules(router, getModules(modules), false)
+}
+
+func initModules(router *gin.Engine, modules []Module, mock bool) error {
+	if err := validate(router, modules, mock); err != nil {
+		return err
+	}
+
+	return register(router, modules, mock)
+}
+
+type registration struct {
+	method   string
+	path     string
+	handlers []gin.HandlerFunc
+}
+
+// register adds module routes to the router, routes are tried on a scratch router first
+// so nothing is registered when gin rejects any of them
+func register(router *gin.Engine, modules []Module, mock bool) error {
+	routes := []registration{}
 
-		for _, middleware := range module.Middleware {
-			group.Use(middleware)
+	walk(modules, func(s *scope, routePath string, route Route) {
+		if mock {
+			routes = append(routes, registration{route.Method, routePath, mockHandlers(s, route)})
+			return
 		}
 
-		for _, route := range module.Routes {
-			group.Handle(route.Method, route.Path, append(route.Middleware, route.Handler)...)
+		routes = append(routes, registration{route.Method, routePath, handlers(s, route)})
+	})
+
+	if err := tryRegister(router.Routes(), routes); err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		router.Handle(route.method, route.path, route.handlers...)
+	}
+
+	return nil
+}
+
+// tryRegister registers existing and new routes on a scratch router and converts gin panic into error
+func tryRegister(existing gin.RoutesInfo, routes []registration) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrInvalidRoute, r)
 		}
+	}()
+
+	scratch := gin.New()
+
+	for _, info := range existing {
+		scratch.Handle(info.Method, info.Path, info.HandlerFunc)
+	}
+
+	for _, route := range routes {
+		scratch.Handle(route.method, route.path, route.handlers...)
 	}
 
 	return nil
 }
+
+// handlers builds the route handlers chain: deprecation headers, module middleware,
+// route middleware, feature flags check and the handler itself
+func handlers(s *scope, route Route) []gin.HandlerFunc {
+	chain := []gin.HandlerFunc{}
+
+	if deprecation := s.routeDeprecation(route); deprecation != nil {
+		chain = append(chain, deprecated(deprecation))
+	}
+
+	chain = append(chain, s.middleware...)
+	chain = append(chain, route.Middleware...)
+
+	if flags := s.routeFlags(route); len(flags) > 0 {
+		chain = append(chain, flagged(s.flagProvider, flags, s.flagResponse))
+	}
+
+	switch {
+	case route.Socket != nil:
+		return append(chain, s.streams.socket(route.Socket))
+	case route.Stream != nil:
+		return append(chain, s.streams.stream(route.Stream))
+	case route.HandlerE != nil:
+		return append(chain, handlerE(route.HandlerE, s.errorHandler))
+	}
+
+	return append(chain, route.Handler)
+}
@@ -15,6 +15,8 @@ const initTestModule = "test"
 const initTestURL = "/hello"
 const initTestStatus = http.StatusOK
 const initTestResponse = "hello world"
+const initTestChildModule = "child"
+const initTestMiddlewareHeader = "X-Middleware"
 
 var initTestModules = []func() Module{
 	func() Module {
@@ -51,3 +53,77 @@ func TestInit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, initTestResponse, string(data))
 }
+
+func initTestMiddleware(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add(initTestMiddlewareHeader, name)
+		c.Next()
+	}
+}
+
+func TestInitNested(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler := func(c *gin.Context) {
+		c.String(initTestStatus, initTestResponse)
+	}
+
+	err := Init(router, []func() Module{
+		func() Module {
+			return Module{
+				Path:       initTestModule,
+				Middleware: []gin.HandlerFunc{initTestMiddleware("parent")},
+				Routes: []Route{
+					{
+						Path:    initTestURL,
+						Method:  http.MethodGet,
+						Handler: handler,
+					},
+				},
+				Modules: []Module{
+					{
+						Path:       initTestChildModule,
+						Middleware: []gin.HandlerFunc{initTestMiddleware("child")},
+						Routes: []Route{
+							{
+								Path:    initTestURL,
+								Method:  http.MethodGet,
+								Handler: handler,
+							},
+						},
+					},
+				},
+			}
+		},
+	})
+	assert.NoError(err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for _, test := range []struct {
+		URL        string
+		Middleware []string
+	}{
+		{
+			fmt.Sprintf("%s/%s%s", srv.URL, initTestModule, initTestURL),
+			[]string{"parent"},
+		},
+		{
+			fmt.Sprintf("%s/%s/%s%s", srv.URL, initTestModule, initTestChildModule, initTestURL),
+			[]string{"parent", "child"},
+		},
+	} {
+		res, err := http.Get(test.URL)
+		assert.NoError(err)
+		defer res.Body.Close()
+
+		data, err := ioutil.ReadAll(res.Body)
+		assert.NoError(err)
+		assert.Equal(initTestStatus, res.StatusCode)
+		assert.Equal(initTestResponse, string(data))
+		assert.Equal(test.Middleware, res.Header.Values(initTestMiddlewareHeader))
+	}
+}
@@ -0,0 +1,22 @@
+package httpmod
+
+// Meta optional route documentation used to generate openapi spec
+type Meta struct {
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Request     interface{}         `json:"request,omitempty"`
+	Responses   map[int]interface{} `json:"responses,omitempty"`
+	Params      []Param             `json:"params,omitempty"`
+	Security    []string            `json:"security,omitempty"`
+}
+
+// Param route parameter description, "In" is one of "path", "query", "header" or "cookie"
+// type is a go value used to infer the schema, defaults to string
+type Param struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Type        interface{} `json:"type,omitempty"`
+}
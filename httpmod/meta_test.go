@@ -0,0 +1,12 @@
+package httpmod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeta(t *testing.T) {
+	assert.NotNil(t, new(Meta))
+	assert.NotNil(t, new(Param))
+}
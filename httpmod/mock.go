@@ -0,0 +1,98 @@
+package httpmod
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// MockScenarioHeader header to select route example by name in mock mode
+const MockScenarioHeader = "X-Mock-Scenario"
+
+// Example route response example served in mock mode
+// Body is written as is for string and []byte, other values are encoded as JSON
+type Example struct {
+	Name    string            `json:"name,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// InitMock create modules serving route examples instead of calling handlers
+// module and route middleware is skipped so no backing stores are required,
+// the first example is served unless MockScenarioHeader selects another one by name
+func InitMock(router *gin.Engine, modules []func() Module) error {
+	if len(modules) <= 0 {
+		return ErrEmptyModules
+	}
+
+	return initModules(router, getModules(modules), true)
+}
+
+func mockHandlers(s *scope, route Route) []gin.HandlerFunc {
+	chain := []gin.HandlerFunc{}
+
+	if deprecation := s.routeDeprecation(route); deprecation != nil {
+		chain = append(chain, deprecated(deprecation))
+	}
+
+	return append(chain, mock(route.Examples))
+}
+
+func mock(examples []Example) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(examples) <= 0 {
+			httperr.Respond(c, httperr.NewError(http.StatusNotImplemented, "route has no examples"))
+			return
+		}
+
+		example := examples[0]
+
+		if scenario := c.GetHeader(MockScenarioHeader); len(scenario) > 0 {
+			found := false
+
+			for _, candidate := range examples {
+				if candidate.Name == scenario {
+					example, found = candidate, true
+					break
+				}
+			}
+
+			if !found {
+				httperr.BadRequest(c, fmt.Sprintf("unknown mock scenario: %s", scenario))
+				return
+			}
+		}
+
+		for name, value := range example.Headers {
+			c.Header(name, value)
+		}
+
+		status := example.Status
+
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		switch body := example.Body.(type) {
+		case nil:
+			c.Status(status)
+		case string:
+			c.Data(status, contentType(c, "text/plain; charset=utf-8"), []byte(body))
+		case []byte:
+			c.Data(status, contentType(c, "application/octet-stream"), body)
+		default:
+			c.JSON(status, body)
+		}
+	}
+}
+
+func contentType(c *gin.Context, fallback string) string {
+	if value := c.Writer.Header().Get("Content-Type"); len(value) > 0 {
+		return value
+	}
+
+	return fallback
+}
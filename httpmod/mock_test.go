@@ -0,0 +1,97 @@
+package httpmod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitMock(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	assert.Equal(ErrEmptyModules, InitMock(router, []func() Module{}))
+
+	err := InitMock(router, []func() Module{
+		func() Module {
+			return Module{
+				Path: "users",
+				Middleware: []gin.HandlerFunc{
+					func(c *gin.Context) {
+						panic("middleware should be skipped in mock mode")
+					},
+				},
+				Routes: []Route{
+					{
+						Path:   "/:id",
+						Method: http.MethodGet,
+						Handler: func(c *gin.Context) {
+							panic("handler should be skipped in mock mode")
+						},
+						Examples: []Example{
+							{
+								Name:    "found",
+								Headers: map[string]string{"X-Total": "1"},
+								Body:    map[string]string{"name": "john"},
+							},
+							{
+								Name:   "missing",
+								Status: http.StatusNotFound,
+								Body:   "not found",
+							},
+						},
+					},
+					{
+						Path:   "/",
+						Method: http.MethodPost,
+					},
+				},
+			}
+		},
+	})
+	assert.NoError(err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for _, test := range []struct {
+		Method   string
+		Scenario string
+		Path     string
+		Status   int
+		Body     string
+		Header   string
+	}{
+		{http.MethodGet, "", "/users/1", http.StatusOK, `{"name":"john"}`, "1"},
+		{http.MethodGet, "found", "/users/1", http.StatusOK, `{"name":"john"}`, "1"},
+		{http.MethodGet, "missing", "/users/1", http.StatusNotFound, "not found", ""},
+		{http.MethodGet, "unknown", "/users/1", http.StatusBadRequest, "", ""},
+		{http.MethodPost, "", "/users/", http.StatusNotImplemented, "", ""},
+	} {
+		req, err := http.NewRequest(test.Method, fmt.Sprintf("%s%s", srv.URL, test.Path), nil)
+		assert.NoError(err)
+
+		if len(test.Scenario) > 0 {
+			req.Header.Set(MockScenarioHeader, test.Scenario)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(err)
+		defer res.Body.Close()
+
+		assert.Equal(test.Status, res.StatusCode)
+		assert.Equal(test.Header, res.Header.Get("X-Total"))
+
+		if len(test.Body) > 0 {
+			data, err := ioutil.ReadAll(res.Body)
+			assert.NoError(err)
+			assert.Equal(test.Body, string(data))
+		}
+	}
+}
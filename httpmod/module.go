@@ -1,10 +1,30 @@
 package httpmod
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
 
 // Module struct to represent module
+// nested modules are mounted under the parent path and inherit parent middleware
+// and error handler (unless they provide their own)
+// OnStart and OnStop hooks are called by Run, modules are stopped in reverse order
+// Version (e.g. "v1") is prepended to the module path, see Versions for header based selection
+// Flag disables all module routes when off, Flags provider and FlagResponse (defaults to 404) are inherited
+// Streams registry is inherited and required for websocket and SSE routes
 type Module struct {
-	Path       string
-	Middleware []gin.HandlerFunc
-	Routes     []Route
+	Version      string
+	Path         string
+	Middleware   []gin.HandlerFunc
+	Routes       []Route
+	Modules      []Module
+	ErrorHandler ErrorHandler
+	Deprecation  *Deprecation
+	Flag         string
+	Flags        FlagProvider
+	FlagResponse gin.HandlerFunc
+	Streams      *Streams
+	OnStart      func(ctx context.Context) error
+	OnStop       func(ctx context.Context) error
 }
@@ -0,0 +1,436 @@
+package httpmod
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIVersion version of the generated openapi documents
+const OpenAPIVersion = "3.0.3"
+
+// OpenAPIParams document level information for the generated spec
+type OpenAPIParams struct {
+	Title           string
+	Description     string
+	Version         string
+	Servers         []string
+	SecuritySchemes map[string]*OpenAPISecurityScheme
+}
+
+// OpenAPI openapi 3 document
+type OpenAPI struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Servers    []OpenAPIServer                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components *OpenAPIComponents                      `json:"components,omitempty"`
+}
+
+// OpenAPIInfo document info object
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// OpenAPIServer document server object
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+// OpenAPIComponents reusable schemas and security schemes
+type OpenAPIComponents struct {
+	Schemas         map[string]*OpenAPISchema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// OpenAPISecurityScheme security scheme object
+type OpenAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// OpenAPIOperation single method on a path
+type OpenAPIOperation struct {
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+}
+
+// OpenAPIParameter operation parameter
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody operation request body
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse operation response
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType content of request or response
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema json schema subset used by openapi
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// JSON encode document as JSON
+func (o *OpenAPI) JSON() ([]byte, error) {
+	return json.MarshalIndent(o, "", "  ")
+}
+
+// YAML encode document as YAML, keeping the field order of the JSON document
+func (o *OpenAPI) YAML() ([]byte, error) {
+	data, err := json.Marshal(o)
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc := new(yaml.Node)
+
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+
+	blockStyle(doc)
+
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// blockStyle drops JSON flow and quoting styles so the document is encoded as plain YAML
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// NewOpenAPI generate openapi document from the modules passed to Init
+func NewOpenAPI(p *OpenAPIParams, modules []func() Module) (*OpenAPI, error) {
+	if len(modules) <= 0 {
+		return nil, ErrEmptyModules
+	}
+
+	doc := &OpenAPI{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       p.Title,
+			Description: p.Description,
+			Version:     p.Version,
+		},
+		Paths: map[string]map[string]*OpenAPIOperation{},
+	}
+
+	for _, url := range p.Servers {
+		doc.Servers = append(doc.Servers, OpenAPIServer{url})
+	}
+
+	schemas := newSchemaRegistry()
+
+	walk(getModules(modules), func(s *scope, routePath string, route Route) {
+		path, params := openAPIPath(routePath)
+
+		if _, ok := doc.Paths[path]; !ok {
+			doc.Paths[path] = map[string]*OpenAPIOperation{}
+		}
+
+		op := newOpenAPIOperation(schemas, params, route.Meta)
+		op.Deprecated = s.routeDeprecation(route) != nil
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	})
+
+	if len(schemas.schemas) > 0 || len(p.SecuritySchemes) > 0 {
+		doc.Components = &OpenAPIComponents{
+			Schemas:         schemas.schemas,
+			SecuritySchemes: p.SecuritySchemes,
+		}
+	}
+
+	return doc, nil
+}
+
+func newOpenAPIOperation(schemas *schemaRegistry, params []string, meta *Meta) *OpenAPIOperation {
+	op := &OpenAPIOperation{
+		Responses: map[string]*OpenAPIResponse{},
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, &OpenAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &OpenAPISchema{Type: "string"},
+		})
+	}
+
+	if meta == nil {
+		meta = new(Meta)
+	}
+
+	op.Summary = meta.Summary
+	op.Description = meta.Description
+	op.Tags = meta.Tags
+
+	for _, param := range meta.Params {
+		parameter := &OpenAPIParameter{
+			Name:        param.Name,
+			In:          param.In,
+			Description: param.Description,
+			Required:    param.Required || param.In == "path",
+			Schema:      &OpenAPISchema{Type: "string"},
+		}
+
+		if param.Type != nil {
+			parameter.Schema = schemas.schema(reflect.TypeOf(param.Type))
+		}
+
+		replaced := false
+
+		for i, existing := range op.Parameters {
+			if existing.Name == param.Name && existing.In == param.In {
+				op.Parameters[i] = parameter
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			op.Parameters = append(op.Parameters, parameter)
+		}
+	}
+
+	if meta.Request != nil {
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]*OpenAPIMediaType{
+				"application/json": {schemas.schema(reflect.TypeOf(meta.Request))},
+			},
+		}
+	}
+
+	for status, body := range meta.Responses {
+		res := &OpenAPIResponse{
+			Description: http.StatusText(status),
+		}
+
+		if body != nil {
+			res.Content = map[string]*OpenAPIMediaType{
+				"application/json": {schemas.schema(reflect.TypeOf(body))},
+			}
+		}
+
+		op.Responses[strconv.Itoa(status)] = res
+	}
+
+	if len(op.Responses) <= 0 {
+		op.Responses["default"] = &OpenAPIResponse{Description: "Default response"}
+	}
+
+	for _, name := range meta.Security {
+		op.Security = append(op.Security, map[string][]string{name: {}})
+	}
+
+	return op
+}
+
+// openAPIPath converts gin path parameters (":id", "*path") into openapi templates
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	params := []string{}
+
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type schemaRegistry struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		map[string]*OpenAPISchema{},
+		map[reflect.Type]string{},
+	}
+}
+
+func (r *schemaRegistry) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &OpenAPISchema{Ref: "#/components/schemas/" + r.define(t)}
+	}
+
+	return r.inline(t)
+}
+
+func (r *schemaRegistry) define(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+
+	if _, ok := r.schemas[name]; ok {
+		name = strings.Replace(t.String(), ".", "_", -1)
+	}
+
+	r.names[t] = name
+	r.schemas[name] = new(OpenAPISchema)
+	*r.schemas[name] = *r.inline(t)
+
+	return name
+}
+
+func (r *schemaRegistry) inline(t reflect.Type) *OpenAPISchema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &OpenAPISchema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+
+		return &OpenAPISchema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+		r.properties(t, schema)
+		sort.Strings(schema.Required)
+		return schema
+	}
+
+	return new(OpenAPISchema)
+}
+
+func (r *schemaRegistry) properties(t reflect.Type, schema *OpenAPISchema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts := field.Name, ""
+
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+
+			parts := strings.SplitN(tag, ",", 2)
+
+			if len(parts[0]) > 0 {
+				name = parts[0]
+			}
+
+			if len(parts) > 1 {
+				opts = parts[1]
+			}
+		}
+
+		if field.Anonymous && name == field.Name {
+			embedded := field.Type
+
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				r.properties(embedded, schema)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		schema.Properties[name] = r.schema(field.Type)
+
+		if hasRule(field.Tag.Get("binding"), "required") && !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func hasRule(tag string, rule string) bool {
+	for _, item := range strings.Split(tag, ",") {
+		if item == rule {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package httpmod
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type openAPITestUser struct {
+	ID        int               `json:"id"`
+	Name      string            `json:"name" binding:"required"`
+	Tags      []string          `json:"tags,omitempty"`
+	Meta      map[string]string `json:"meta"`
+	CreatedAt time.Time         `json:"created_at"`
+	Friends   []*openAPITestUser
+	password  string
+}
+
+var openAPITestParams = &OpenAPIParams{
+	Title:   "test",
+	Version: "1.0.0",
+	Servers: []string{"http://localhost:8080"},
+	SecuritySchemes: map[string]*OpenAPISecurityScheme{
+		"bearer": {Type: "http", Scheme: "bearer"},
+	},
+}
+
+var openAPITestModules = []func() Module{
+	func() Module {
+		return Module{
+			Path: "v1",
+			Modules: []Module{
+				{
+					Path: "users",
+					Routes: []Route{
+						{
+							Path:    "/:id",
+							Method:  http.MethodGet,
+							Handler: func(c *gin.Context) {},
+							Meta: &Meta{
+								Summary:  "get user",
+								Tags:     []string{"users"},
+								Security: []string{"bearer"},
+								Params: []Param{
+									{Name: "id", In: "path", Type: 0},
+									{Name: "fields", In: "query"},
+								},
+								Responses: map[int]interface{}{
+									http.StatusOK:       openAPITestUser{},
+									http.StatusNotFound: nil,
+								},
+							},
+						},
+						{
+							Path:    "/",
+							Method:  http.MethodPost,
+							Handler: func(c *gin.Context) {},
+							Meta: &Meta{
+								Request: new(openAPITestUser),
+							},
+						},
+					},
+				},
+			},
+		}
+	},
+}
+
+func TestOpenAPI(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewOpenAPI(openAPITestParams, []func() Module{})
+	assert.Equal(ErrEmptyModules, err)
+
+	doc, err := NewOpenAPI(openAPITestParams, openAPITestModules)
+	assert.NoError(err)
+	assert.Equal(OpenAPIVersion, doc.OpenAPI)
+	assert.Equal("http://localhost:8080", doc.Servers[0].URL)
+
+	get := doc.Paths["/v1/users/{id}"]["get"]
+	assert.NotNil(get)
+	assert.Equal("get user", get.Summary)
+	assert.Equal([]string{"users"}, get.Tags)
+	assert.Equal([]map[string][]string{{"bearer": {}}}, get.Security)
+	assert.Len(get.Parameters, 2)
+	assert.Equal("id", get.Parameters[0].Name)
+	assert.Equal("integer", get.Parameters[0].Schema.Type)
+	assert.True(get.Parameters[0].Required)
+	assert.Equal("query", get.Parameters[1].In)
+	assert.Equal("#/components/schemas/openAPITestUser", get.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal("Not Found", get.Responses["404"].Description)
+	assert.Nil(get.Responses["404"].Content)
+
+	post := doc.Paths["/v1/users/"]["post"]
+	assert.NotNil(post)
+	assert.NotNil(post.Responses["default"])
+	assert.Equal("#/components/schemas/openAPITestUser", post.RequestBody.Content["application/json"].Schema.Ref)
+
+	user := doc.Components.Schemas["openAPITestUser"]
+	assert.Equal("object", user.Type)
+	assert.Equal([]string{"name"}, user.Required)
+	assert.Equal("integer", user.Properties["id"].Type)
+	assert.Equal("array", user.Properties["tags"].Type)
+	assert.Equal("string", user.Properties["meta"].AdditionalProperties.Type)
+	assert.Equal("date-time", user.Properties["created_at"].Format)
+	assert.Equal("#/components/schemas/openAPITestUser", user.Properties["Friends"].Items.Ref)
+	assert.NotContains(user.Properties, "password")
+	assert.Equal("bearer", doc.Components.SecuritySchemes["bearer"].Scheme)
+
+	data, err := doc.JSON()
+	assert.NoError(err)
+	assert.True(json.Valid(data))
+
+	data, err = doc.YAML()
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(string(data), "openapi: 3.0.3\ninfo:\n"))
+
+	result := map[string]interface{}{}
+	assert.NoError(yaml.Unmarshal(data, &result))
+	assert.Equal(OpenAPIVersion, result["openapi"])
+}
@@ -5,9 +5,21 @@ import (
 )
 
 // Route struct to represent single route
+// HandlerE can be used instead of Handler, returned errors are passed to module ErrorHandler
+// Deprecation overrides the one inherited from module
+// Flag disables the route when off, in addition to flags of parent modules
+// Examples are served instead of the handler in mock mode
+// Socket and Stream turn the route into websocket or server-sent events endpoint, see Streams
 type Route struct {
-	Path       string
-	Method     string
-	Middleware []gin.HandlerFunc
-	Handler    func(c *gin.Context)
+	Path        string
+	Method      string
+	Middleware  []gin.HandlerFunc
+	Handler     func(c *gin.Context)
+	HandlerE    func(c *gin.Context) error
+	Socket      SocketHandler
+	Stream      StreamHandler
+	Meta        *Meta
+	Deprecation *Deprecation
+	Flag        string
+	Examples    []Example
 }
@@ -0,0 +1,299 @@
+package httpmod
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	RPCParseError     = -32700
+	RPCInvalidRequest = -32600
+	RPCMethodNotFound = -32601
+	RPCInvalidParams  = -32602
+	RPCInternalError  = -32603
+	RPCServerError    = -32000
+)
+
+const rpcVersion = "2.0"
+
+var (
+	rpcContextType = reflect.TypeOf(new(gin.Context))
+	rpcErrorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// RPCError JSON-RPC error object, can be returned by methods to respond with specific code
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Error message of the rpc error
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcMethod struct {
+	fn     reflect.Value
+	params reflect.Type
+	result bool
+}
+
+// NewRPC creates JSON-RPC 2.0 endpoint builder
+func NewRPC() *RPC {
+	return &RPC{
+		methods: map[string]*rpcMethod{},
+	}
+}
+
+// RPC JSON-RPC 2.0 endpoint exposing registered go functions, safe for concurrent use
+type RPC struct {
+	mut     sync.RWMutex
+	methods map[string]*rpcMethod
+}
+
+// Register adds named method, fn signature should be one of:
+// * func(c *gin.Context, params T) (R, error)
+// * func(c *gin.Context) (R, error)
+// * func(c *gin.Context, params T) error
+// * func(c *gin.Context) error
+// params are decoded from JSON into T, errors carrying http status are mapped to rpc error codes
+func (r *RPC) Register(name string, fn interface{}) error {
+	val := reflect.ValueOf(fn)
+
+	if !val.IsValid() {
+		return fmt.Errorf("invalid rpc method %s signature: %v", name, fn)
+	}
+
+	typ := val.Type()
+
+	if typ.Kind() != reflect.Func ||
+		typ.NumIn() < 1 || typ.NumIn() > 2 || typ.In(0) != rpcContextType ||
+		typ.NumOut() < 1 || typ.NumOut() > 2 || typ.Out(typ.NumOut()-1) != rpcErrorType {
+		return fmt.Errorf("invalid rpc method %s signature: %s", name, typ)
+	}
+
+	method := &rpcMethod{
+		fn:     val,
+		result: typ.NumOut() == 2,
+	}
+
+	if typ.NumIn() == 2 {
+		method.params = typ.In(1)
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.methods[name] = method
+	return nil
+}
+
+// Module creates module with single POST route serving the endpoint
+func (r *RPC) Module(path string, middleware ...gin.HandlerFunc) Module {
+	return Module{
+		Path:       path,
+		Middleware: middleware,
+		Routes: []Route{
+			{
+				Path:    "",
+				Method:  http.MethodPost,
+				Handler: r.Handle,
+			},
+		},
+	}
+}
+
+// Handle serves single and batch JSON-RPC requests
+func (r *RPC) Handle(c *gin.Context) {
+	body, err := c.GetRawData()
+
+	if err != nil {
+		c.JSON(http.StatusOK, rpcFailure(nil, &RPCError{Code: RPCParseError, Message: err.Error()}))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+
+	if len(body) > 0 && body[0] == '[' {
+		batch := []json.RawMessage{}
+
+		if err := json.Unmarshal(body, &batch); err != nil {
+			c.JSON(http.StatusOK, rpcFailure(nil, &RPCError{Code: RPCParseError, Message: "Parse error"}))
+			return
+		}
+
+		if len(batch) <= 0 {
+			c.JSON(http.StatusOK, rpcFailure(nil, &RPCError{Code: RPCInvalidRequest, Message: "Invalid Request"}))
+			return
+		}
+
+		responses := []*rpcResponse{}
+
+		for _, item := range batch {
+			if res := r.call(c, item); res != nil {
+				responses = append(responses, res)
+			}
+		}
+
+		if len(responses) <= 0 {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		c.JSON(http.StatusOK, responses)
+		return
+	}
+
+	if res := r.call(c, body); res != nil {
+		c.JSON(http.StatusOK, res)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// call executes single request, returns nil for notifications
+func (r *RPC) call(c *gin.Context, data json.RawMessage) *rpcResponse {
+	req := new(rpcRequest)
+
+	if err := json.Unmarshal(data, req); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return rpcFailure(nil, &RPCError{Code: RPCParseError, Message: "Parse error"})
+		}
+
+		return rpcFailure(nil, &RPCError{Code: RPCInvalidRequest, Message: "Invalid Request"})
+	}
+
+	if req.JSONRPC != rpcVersion || len(req.Method) <= 0 {
+		return rpcFailure(req.ID, &RPCError{Code: RPCInvalidRequest, Message: "Invalid Request"})
+	}
+
+	result, rpcErr := r.invoke(c, req)
+
+	if req.ID == nil {
+		return nil
+	}
+
+	if rpcErr != nil {
+		return rpcFailure(req.ID, rpcErr)
+	}
+
+	return &rpcResponse{
+		JSONRPC: rpcVersion,
+		Result:  result,
+		ID:      req.ID,
+	}
+}
+
+func (r *RPC) invoke(c *gin.Context, req *rpcRequest) (json.RawMessage, *RPCError) {
+	r.mut.RLock()
+	method, ok := r.methods[req.Method]
+	r.mut.RUnlock()
+
+	if !ok {
+		return nil, &RPCError{Code: RPCMethodNotFound, Message: "Method not found"}
+	}
+
+	args := []reflect.Value{reflect.ValueOf(c)}
+
+	if method.params != nil {
+		params := reflect.New(method.params)
+
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, params.Interface()); err != nil {
+				return nil, &RPCError{Code: RPCInvalidParams, Message: "Invalid params", Data: err.Error()}
+			}
+		}
+
+		args = append(args, params.Elem())
+	}
+
+	out := method.fn.Call(args)
+
+	if err, _ := out[len(out)-1].Interface().(error); err != nil {
+		return nil, rpcErrorFrom(err)
+	}
+
+	if !method.result {
+		return json.RawMessage("null"), nil
+	}
+
+	result, err := json.Marshal(out[0].Interface())
+
+	if err != nil {
+		return nil, &RPCError{Code: RPCInternalError, Message: "Internal error", Data: err.Error()}
+	}
+
+	return result, nil
+}
+
+// rpcErrorFrom maps method error to rpc error: 400 and 422 are invalid params,
+// other 4xx are server errors and the rest are internal errors, httperr.Error is sent as data
+// (messages of 5xx errors are hidden, see httperr.Wrap)
+func rpcErrorFrom(err error) *RPCError {
+	rpcErr := new(RPCError)
+
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+
+	herr := new(httperr.Error)
+
+	if !errors.As(err, &herr) {
+		status := http.StatusInternalServerError
+		var coder StatusCoder
+
+		if errors.As(err, &coder) {
+			status = coder.StatusCode()
+		}
+
+		herr = httperr.Wrap(status, err)
+	}
+
+	rpcErr = &RPCError{
+		Code:    RPCInternalError,
+		Message: herr.Message,
+		Data:    herr,
+	}
+
+	switch {
+	case herr.Status == http.StatusBadRequest || herr.Status == http.StatusUnprocessableEntity:
+		rpcErr.Code = RPCInvalidParams
+	case herr.Status >= 400 && herr.Status < 500:
+		rpcErr.Code = RPCServerError
+	}
+
+	return rpcErr
+}
+
+func rpcFailure(id json.RawMessage, err *RPCError) *rpcResponse {
+	return &rpcResponse{
+		JSONRPC: rpcVersion,
+		Error:   err,
+		ID:      id,
+	}
+}
@@ -0,0 +1,206 @@
+package httpmod
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type rpcStatusErr struct {
+	status int
+}
+
+func (e *rpcStatusErr) Error() string {
+	return http.StatusText(e.status)
+}
+
+func (e *rpcStatusErr) StatusCode() int {
+	return e.status
+}
+
+type rpcAddParams struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func TestRPC(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	calls := 0
+
+	rpc := NewRPC()
+	assert.Error(rpc.Register("invalid", func(a int) int { return a }))
+	assert.Error(rpc.Register("noerror", func(c *gin.Context) int { return 0 }))
+	assert.Error(rpc.Register("nil", nil))
+	assert.NoError(rpc.Register("add", func(c *gin.Context, p rpcAddParams) (int, error) {
+		return p.A + p.B, nil
+	}))
+	assert.NoError(rpc.Register("sum", func(c *gin.Context, p []int) (int, error) {
+		sum := 0
+
+		for _, n := range p {
+			sum += n
+		}
+
+		return sum, nil
+	}))
+	assert.NoError(rpc.Register("user", func(c *gin.Context) (string, error) {
+		return c.GetString("user"), nil
+	}))
+	assert.NoError(rpc.Register("notify", func(c *gin.Context) error {
+		calls++
+		return nil
+	}))
+	assert.NoError(rpc.Register("missing", func(c *gin.Context) (interface{}, error) {
+		return nil, &rpcStatusErr{http.StatusNotFound}
+	}))
+	assert.NoError(rpc.Register("unprocessable", func(c *gin.Context) (interface{}, error) {
+		return nil, &rpcStatusErr{http.StatusUnprocessableEntity}
+	}))
+	assert.NoError(rpc.Register("fail", func(c *gin.Context) (interface{}, error) {
+		return nil, errors.New("db is down")
+	}))
+	assert.NoError(rpc.Register("custom", func(c *gin.Context) (interface{}, error) {
+		return nil, &RPCError{Code: -32001, Message: "custom"}
+	}))
+
+	err := Init(router, []func() Module{
+		func() Module {
+			return rpc.Module("rpc", func(c *gin.Context) {
+				c.Set("user", "john")
+			})
+		},
+	})
+	assert.NoError(err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		res    string
+	}{
+		{
+			"params by name",
+			`{"jsonrpc":"2.0","method":"add","params":{"a":1,"b":2},"id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","result":3,"id":1}`,
+		},
+		{
+			"params by position",
+			`{"jsonrpc":"2.0","method":"sum","params":[1,2,3],"id":"a"}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","result":6,"id":"a"}`,
+		},
+		{
+			"middleware applies",
+			`{"jsonrpc":"2.0","method":"user","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","result":"john","id":1}`,
+		},
+		{
+			"no result",
+			`{"jsonrpc":"2.0","method":"notify","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","result":null,"id":1}`,
+		},
+		{
+			"notification",
+			`{"jsonrpc":"2.0","method":"notify"}`,
+			http.StatusNoContent,
+			``,
+		},
+		{
+			"parse error",
+			`{"jsonrpc":"2.0","method"`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`,
+		},
+		{
+			"invalid request",
+			`{"jsonrpc":"1.0","method":"add","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":1}`,
+		},
+		{
+			"method not found",
+			`{"jsonrpc":"2.0","method":"unknown","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`,
+		},
+		{
+			"invalid params",
+			`{"jsonrpc":"2.0","method":"add","params":[1,2],"id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params","data":"json: cannot unmarshal array into Go value of type httpmod.rpcAddParams"},"id":1}`,
+		},
+		{
+			"client error",
+			`{"jsonrpc":"2.0","method":"missing","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32000,"message":"Not Found","data":{"status":404,"message":"Not Found"}},"id":1}`,
+		},
+		{
+			"unprocessable entity",
+			`{"jsonrpc":"2.0","method":"unprocessable","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Unprocessable Entity","data":{"status":422,"message":"Unprocessable Entity"}},"id":1}`,
+		},
+		{
+			"internal error",
+			`{"jsonrpc":"2.0","method":"fail","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32603,"message":"Internal Server Error","data":{"status":500,"message":"Internal Server Error"}},"id":1}`,
+		},
+		{
+			"custom error",
+			`{"jsonrpc":"2.0","method":"custom","id":1}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32001,"message":"custom"},"id":1}`,
+		},
+		{
+			"batch",
+			`[{"jsonrpc":"2.0","method":"add","params":{"a":1,"b":1},"id":1},{"jsonrpc":"2.0","method":"notify"},1]`,
+			http.StatusOK,
+			`[{"jsonrpc":"2.0","result":2,"id":1},{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}]`,
+		},
+		{
+			"empty batch",
+			`[]`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`,
+		},
+		{
+			"notifications batch",
+			`[{"jsonrpc":"2.0","method":"notify"},{"jsonrpc":"2.0","method":"notify"}]`,
+			http.StatusNoContent,
+			``,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := http.Post(srv.URL+"/rpc", "application/json", strings.NewReader(test.body))
+		assert.NoError(err, test.name)
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		assert.NoError(err, test.name)
+		assert.Equal(test.status, res.StatusCode, test.name)
+
+		if len(test.res) > 0 {
+			assert.JSONEq(test.res, string(body), test.name)
+		} else {
+			assert.Empty(body, test.name)
+		}
+	}
+
+	assert.Equal(5, calls)
+}
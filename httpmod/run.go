@@ -0,0 +1,162 @@
+package httpmod
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultDrainTimeout time given to in-flight requests and module OnStop hooks on shutdown
+const DefaultDrainTimeout = time.Second * 30
+
+// RunParams http server runner parameters
+// Listener is used instead of Addr when provided, Router defaults to gin.New()
+// Versioning enables header based version selection, known versions are taken from modules if not provided
+// Mock serves route examples instead of real handlers, see InitMock, OnStart and OnStop hooks are not called in mock mode
+type RunParams struct {
+	Addr         string
+	Listener     net.Listener
+	Router       *gin.Engine
+	Modules      []func() Module
+	DrainTimeout time.Duration
+	Versioning   *VersionParams
+	Mock         bool
+}
+
+// Run creates modules, calls OnStart hooks and serves http until the context is canceled
+// or SIGINT/SIGTERM is received, after that closes module streams, drains the server
+// and calls OnStop hooks in reverse order
+func Run(ctx context.Context, p *RunParams) error {
+	if len(p.Modules) <= 0 {
+		return ErrEmptyModules
+	}
+
+	router := p.Router
+
+	if router == nil {
+		router = gin.New()
+	}
+
+	modules := getModules(p.Modules)
+
+	if err := initModules(router, modules, p.Mock); err != nil {
+		return err
+	}
+
+	timeout := p.DrainTimeout
+
+	if timeout <= 0 {
+		timeout = DefaultDrainTimeout
+	}
+
+	hooks := []Module{}
+
+	if !p.Mock {
+		hooks = flatten(modules)
+	}
+
+	for i, module := range hooks {
+		if module.OnStart == nil {
+			continue
+		}
+
+		if err := module.OnStart(ctx); err != nil {
+			stopCtx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			stop(stopCtx, hooks[:i])
+			return err
+		}
+	}
+
+	srv := &http.Server{
+		Addr:    p.Addr,
+		Handler: router,
+	}
+
+	if p.Versioning != nil {
+		versioning := *p.Versioning
+
+		if len(versioning.Versions) <= 0 {
+			versioning.Versions = versions(modules)
+		}
+
+		srv.Handler = Versions(router, &versioning)
+	}
+
+	errs := make(chan error, 1)
+
+	go func() {
+		if p.Listener != nil {
+			errs <- srv.Serve(p.Listener)
+		} else {
+			errs <- srv.ListenAndServe()
+		}
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	var err error
+
+	select {
+	case <-ctx.Done():
+	case <-sig:
+	case err = <-errs:
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for _, module := range hooks {
+		if module.Streams != nil {
+			module.Streams.Shutdown(stopCtx)
+		}
+	}
+
+	if shutdownErr := srv.Shutdown(stopCtx); err == nil || err == http.ErrServerClosed {
+		err = shutdownErr
+	}
+
+	if stopErr := stop(stopCtx, hooks); err == nil {
+		err = stopErr
+	}
+
+	return err
+}
+
+// stop calls OnStop hooks in reverse order and returns the first error
+func stop(ctx context.Context, modules []Module) error {
+	var err error
+
+	for i := len(modules) - 1; i >= 0; i-- {
+		if modules[i].OnStop == nil {
+			continue
+		}
+
+		if stopErr := modules[i].OnStop(ctx); stopErr != nil && err == nil {
+			err = stopErr
+		}
+	}
+
+	return err
+}
+
+// flatten lists modules depth first, parents before children
+func flatten(modules []Module) []Module {
+	result := []Module{}
+
+	for _, module := range modules {
+		result = append(result, module)
+		result = append(result, flatten(module.Modules)...)
+	}
+
+	return result
+}
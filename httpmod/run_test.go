@@ -0,0 +1,179 @@
+package httpmod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type runTestHooks struct {
+	calls chan string
+}
+
+func (h *runTestHooks) module(name string, err error, children ...Module) Module {
+	return Module{
+		Path:    name,
+		Modules: children,
+		OnStart: func(_ context.Context) error {
+			h.calls <- "start " + name
+			return err
+		},
+		OnStop: func(_ context.Context) error {
+			h.calls <- "stop " + name
+			return nil
+		},
+	}
+}
+
+func (h *runTestHooks) list() []string {
+	close(h.calls)
+	calls := []string{}
+
+	for call := range h.calls {
+		calls = append(calls, call)
+	}
+
+	return calls
+}
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	t.Run("serve and stop", func(t *testing.T) {
+		hooks := &runTestHooks{make(chan string, 10)}
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- Run(ctx, &RunParams{
+				Listener:     listener,
+				DrainTimeout: time.Second,
+				Modules: []func() Module{
+					func() Module {
+						module := hooks.module("parent", nil, hooks.module("child", nil))
+						module.Routes = []Route{
+							{
+								Path:   "/hello",
+								Method: http.MethodGet,
+								Handler: func(c *gin.Context) {
+									c.String(initTestStatus, initTestResponse)
+								},
+							},
+						}
+
+						return module
+					},
+				},
+			})
+		}()
+
+		assert.Eventually(func() bool {
+			res, err := http.Get(fmt.Sprintf("http://%s/parent/hello", listener.Addr()))
+
+			if err != nil {
+				return false
+			}
+
+			defer res.Body.Close()
+			data, err := ioutil.ReadAll(res.Body)
+
+			return err == nil && string(data) == initTestResponse
+		}, time.Second, time.Millisecond*10)
+
+		cancel()
+		assert.NoError(<-done)
+		assert.Equal([]string{"start parent", "start child", "stop child", "stop parent"}, hooks.list())
+	})
+
+	t.Run("start failure", func(t *testing.T) {
+		hooks := &runTestHooks{make(chan string, 10)}
+		errStart := errors.New("can't connect")
+
+		err := Run(context.Background(), &RunParams{
+			Addr: "127.0.0.1:0",
+			Modules: []func() Module{
+				func() Module {
+					return hooks.module("first", nil)
+				},
+				func() Module {
+					return hooks.module("second", errStart)
+				},
+			},
+		})
+
+		assert.Equal(errStart, err)
+		assert.Equal([]string{"start first", "start second", "stop first"}, hooks.list())
+	})
+
+	t.Run("mock", func(t *testing.T) {
+		hooks := &runTestHooks{make(chan string, 10)}
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- Run(ctx, &RunParams{
+				Listener:     listener,
+				DrainTimeout: time.Second,
+				Mock:         true,
+				Modules: []func() Module{
+					func() Module {
+						module := hooks.module("mocked", errors.New("can't connect"))
+						module.Flag = "beta"
+						module.Routes = []Route{
+							{
+								Path:     "/hello",
+								Method:   http.MethodGet,
+								Examples: []Example{{Body: initTestResponse}},
+							},
+							{
+								Path:   "/events",
+								Method: http.MethodGet,
+								Stream: func(ctx context.Context, c *gin.Context, events chan<- Event) error {
+									return nil
+								},
+							},
+						}
+
+						return module
+					},
+				},
+			})
+		}()
+
+		assert.Eventually(func() bool {
+			res, err := http.Get(fmt.Sprintf("http://%s/mocked/hello", listener.Addr()))
+
+			if err != nil {
+				return false
+			}
+
+			defer res.Body.Close()
+			data, err := ioutil.ReadAll(res.Body)
+
+			return err == nil && string(data) == initTestResponse
+		}, time.Second, time.Millisecond*10)
+
+		cancel()
+		assert.NoError(<-done)
+		assert.Empty(hooks.list())
+	})
+
+	t.Run("empty modules", func(t *testing.T) {
+		assert.Equal(ErrEmptyModules, Run(context.Background(), new(RunParams)))
+	})
+}
@@ -0,0 +1,299 @@
+package httpmod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+)
+
+// ErrNoStreams websocket or SSE route has no Streams set in the module tree
+var ErrNoStreams = errors.New("no streams")
+
+// DefaultHeartbeat interval of websocket pings and SSE keepalive comments
+const DefaultHeartbeat = time.Second * 30
+
+// Event server-sent event
+type Event struct {
+	ID    string
+	Event string
+	Retry uint
+	Data  interface{}
+}
+
+// SocketHandler handles upgraded websocket connection, ctx is canceled when ping can't be written or on shutdown
+// connection is closed with normal closure when handler returns, with internal error when it fails
+// and with going away on shutdown
+// pongs and read deadline are processed only while handler reads, so write-only handlers should keep reading
+// (e.g. discard conn.NextReader in a goroutine) to detect dead peers
+type SocketHandler func(ctx context.Context, c *gin.Context, conn *websocket.Conn) error
+
+// StreamHandler sends server-sent events until it returns, ctx is canceled when client disconnects
+// or on shutdown, handler should stop sending events after that
+type StreamHandler func(ctx context.Context, c *gin.Context, events chan<- Event) error
+
+// NewStreams creates long-lived connections registry
+// heartbeat defaults to DefaultHeartbeat, maxPerUser limits connections per user (username or client IP), 0 means unlimited
+func NewStreams(heartbeat time.Duration, maxPerUser int) *Streams {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+
+	return &Streams{
+		heartbeat:  heartbeat,
+		maxPerUser: maxPerUser,
+		users:      map[string]int{},
+		cancels:    map[*context.CancelFunc]struct{}{},
+	}
+}
+
+// Streams registry of websocket and SSE connections, shared by modules through Module.Streams
+// Upgrader can be adjusted before serving (e.g. CheckOrigin)
+type Streams struct {
+	Upgrader   websocket.Upgrader
+	heartbeat  time.Duration
+	maxPerUser int
+	mut        sync.Mutex
+	users      map[string]int
+	cancels    map[*context.CancelFunc]struct{}
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+// Shutdown cancels all connections and waits for the handlers to return
+func (s *Streams) Shutdown(ctx context.Context) error {
+	s.mut.Lock()
+	s.closed = true
+
+	for cancel := range s.cancels {
+		(*cancel)()
+	}
+
+	s.mut.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire registers connection, responds with error if it is not allowed
+func (s *Streams) acquire(c *gin.Context) (context.Context, func(), bool) {
+	id := c.ClientIP()
+
+	if model, ok := c.Get("user"); ok {
+		if user, ok := model.(*httpmw.CognitoUser); ok && len(user.GetUsername()) > 0 {
+			id = user.GetUsername()
+		}
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.closed {
+		httperr.ServiceUnavailable(c, "server is shutting down")
+		return nil, nil, false
+	}
+
+	if s.maxPerUser > 0 && s.users[id] >= s.maxPerUser {
+		httperr.TooManyRequests(c, fmt.Sprintf("connections limit of %d reached", s.maxPerUser))
+		return nil, nil, false
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	s.users[id]++
+	s.cancels[&cancel] = struct{}{}
+	s.wg.Add(1)
+
+	return ctx, func() {
+		cancel()
+
+		s.mut.Lock()
+		defer s.mut.Unlock()
+
+		if s.users[id]--; s.users[id] <= 0 {
+			delete(s.users, id)
+		}
+
+		delete(s.cancels, &cancel)
+		s.wg.Done()
+	}, true
+}
+
+func (s *Streams) socket(handler SocketHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, release, ok := s.acquire(c)
+
+		if !ok {
+			c.Abort()
+			return
+		}
+
+		defer release()
+
+		conn, err := s.Upgrader.Upgrade(c.Writer, c.Request, nil)
+
+		if err != nil {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		conn.SetReadDeadline(time.Now().Add(s.heartbeat * 2))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(s.heartbeat * 2))
+		})
+
+		codes := make(chan int, 1)
+		closed := make(chan struct{})
+
+		go func() {
+			defer close(closed)
+
+			ticker := time.NewTicker(s.heartbeat)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					code := websocket.CloseGoingAway
+
+					select {
+					case code = <-codes:
+					default:
+					}
+
+					conn.WriteControl(
+						websocket.CloseMessage,
+						websocket.FormatCloseMessage(code, closeReason(code)),
+						time.Now().Add(time.Second),
+					)
+					conn.Close()
+					return
+				case <-ticker.C:
+					if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.heartbeat)); err != nil {
+						cancel()
+					}
+				}
+			}
+		}()
+
+		if err := handler(ctx, c, conn); err != nil {
+			codes <- websocket.CloseInternalServerErr
+		} else {
+			codes <- websocket.CloseNormalClosure
+		}
+
+		cancel()
+		<-closed
+	}
+}
+
+// closeReason fixed reason of the close code, handler errors are not exposed to clients
+func closeReason(code int) string {
+	if code == websocket.CloseInternalServerErr {
+		return http.StatusText(http.StatusInternalServerError)
+	}
+
+	return ""
+}
+
+func (s *Streams) stream(handler StreamHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, release, ok := s.acquire(c)
+
+		if !ok {
+			c.Abort()
+			return
+		}
+
+		defer release()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+
+		events := make(chan Event)
+		done := make(chan error, 1)
+
+		go func() {
+			done <- handler(ctx, c, events)
+		}()
+
+		ticker := time.NewTicker(s.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event := <-events:
+				err := sse.Encode(c.Writer, sse.Event{
+					Id:    event.ID,
+					Event: event.Event,
+					Retry: event.Retry,
+					Data:  event.Data,
+				})
+
+				if err != nil {
+					cancel()
+					drain(events, done)
+					return
+				}
+
+				c.Writer.Flush()
+			case <-ticker.C:
+				if _, err := c.Writer.WriteString(": keepalive\n\n"); err != nil {
+					cancel()
+					drain(events, done)
+					return
+				}
+
+				c.Writer.Flush()
+			case <-ctx.Done():
+				drain(events, done)
+				return
+			case err := <-done:
+				if err != nil {
+					sse.Encode(c.Writer, sse.Event{Event: "error", Data: err.Error()})
+					c.Writer.Flush()
+				}
+
+				return
+			}
+		}
+	}
+}
+
+// drain discards events sent by the handler after the stream is closed until it returns
+func drain(events <-chan Event, done <-chan error) {
+	for {
+		select {
+		case <-events:
+		case <-done:
+			return
+		}
+	}
+}
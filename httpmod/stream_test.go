@@ -0,0 +1,223 @@
+package httpmod
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamTestServer(streams *Streams) (*httptest.Server, error) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	err := Init(router, []func() Module{
+		func() Module {
+			return Module{
+				Path:    "live",
+				Streams: streams,
+				Routes: []Route{
+					{
+						Path:   "/socket",
+						Method: http.MethodGet,
+						Socket: func(ctx context.Context, c *gin.Context, conn *websocket.Conn) error {
+							for {
+								kind, msg, err := conn.ReadMessage()
+
+								if err != nil {
+									return nil
+								}
+
+								if err := conn.WriteMessage(kind, msg); err != nil {
+									return err
+								}
+							}
+						},
+					},
+					{
+						Path:   "/socket/done",
+						Method: http.MethodGet,
+						Socket: func(ctx context.Context, c *gin.Context, conn *websocket.Conn) error {
+							return nil
+						},
+					},
+					{
+						Path:   "/socket/fail",
+						Method: http.MethodGet,
+						Socket: func(ctx context.Context, c *gin.Context, conn *websocket.Conn) error {
+							return errors.New(strings.Repeat("secret db error ", 10))
+						},
+					},
+					{
+						Path:   "/events",
+						Method: http.MethodGet,
+						Stream: func(ctx context.Context, c *gin.Context, events chan<- Event) error {
+							for i := 0; i < 2; i++ {
+								select {
+								case events <- Event{ID: fmt.Sprint(i), Event: "tick", Data: i}:
+								case <-ctx.Done():
+									return nil
+								}
+							}
+
+							<-ctx.Done()
+							return nil
+						},
+					},
+					{
+						Path:   "/late",
+						Method: http.MethodGet,
+						Stream: func(ctx context.Context, c *gin.Context, events chan<- Event) error {
+							events <- Event{Event: "tick", Data: 0}
+							<-ctx.Done()
+							events <- Event{Event: "tick", Data: 1}
+							return nil
+						},
+					},
+				},
+			}
+		},
+	})
+
+	return httptest.NewServer(router), err
+}
+
+func TestStreams(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("websocket", func(t *testing.T) {
+		streams := NewStreams(time.Millisecond*50, 1)
+		srv, err := streamTestServer(streams)
+		assert.NoError(err)
+		defer srv.Close()
+
+		url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/live/socket"
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		assert.NoError(err)
+		defer conn.Close()
+
+		pings := make(chan struct{}, 10)
+		conn.SetPingHandler(func(string) error {
+			pings <- struct{}{}
+			return nil
+		})
+
+		assert.NoError(conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+		_, msg, err := conn.ReadMessage()
+		assert.NoError(err)
+		assert.Equal("hello", string(msg))
+
+		_, res, err := websocket.DefaultDialer.Dial(url, nil)
+		assert.Equal(websocket.ErrBadHandshake, err)
+		assert.Equal(http.StatusTooManyRequests, res.StatusCode)
+
+		closed := make(chan error)
+
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					closed <- err
+					return
+				}
+			}
+		}()
+
+		assert.Eventually(func() bool {
+			return len(pings) > 0
+		}, time.Second, time.Millisecond*10)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(streams.Shutdown(ctx))
+		assert.True(websocket.IsCloseError(<-closed, websocket.CloseGoingAway))
+
+		_, res, err = websocket.DefaultDialer.Dial(url, nil)
+		assert.Equal(websocket.ErrBadHandshake, err)
+		assert.Equal(http.StatusServiceUnavailable, res.StatusCode)
+	})
+
+	t.Run("websocket close codes", func(t *testing.T) {
+		srv, err := streamTestServer(NewStreams(time.Minute, 0))
+		assert.NoError(err)
+		defer srv.Close()
+
+		for path, code := range map[string]int{
+			"/live/socket/done": websocket.CloseNormalClosure,
+			"/live/socket/fail": websocket.CloseInternalServerErr,
+		} {
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, nil)
+			assert.NoError(err)
+
+			_, _, err = conn.ReadMessage()
+			closeErr := new(websocket.CloseError)
+			assert.True(errors.As(err, &closeErr), path)
+			assert.Equal(code, closeErr.Code, path)
+			assert.NotContains(closeErr.Text, "secret", path)
+			conn.Close()
+		}
+	})
+
+	t.Run("server-sent events", func(t *testing.T) {
+		streams := NewStreams(time.Millisecond*50, 0)
+		srv, err := streamTestServer(streams)
+		assert.NoError(err)
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "/live/events")
+		assert.NoError(err)
+		defer res.Body.Close()
+		assert.Equal("text/event-stream", res.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(res.Body)
+		lines := []string{}
+
+		for len(lines) < 8 {
+			line, err := reader.ReadString('\n')
+			assert.NoError(err)
+
+			if line != "\n" {
+				lines = append(lines, strings.TrimSpace(line))
+			}
+		}
+
+		assert.Equal([]string{"id:0", "event:tick", "data:0", "id:1", "event:tick", "data:1", ": keepalive", ": keepalive"}, lines)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(streams.Shutdown(ctx))
+	})
+
+	t.Run("server-sent events after close", func(t *testing.T) {
+		streams := NewStreams(time.Minute, 0)
+		srv, err := streamTestServer(streams)
+		assert.NoError(err)
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "/live/late")
+		assert.NoError(err)
+		defer res.Body.Close()
+
+		line, err := bufio.NewReader(res.Body).ReadString('\n')
+		assert.NoError(err)
+		assert.Equal("event:tick", strings.TrimSpace(line))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(streams.Shutdown(ctx))
+	})
+
+	t.Run("no streams", func(t *testing.T) {
+		srv, err := streamTestServer(nil)
+		assert.True(errors.Is(err, ErrNoStreams))
+		srv.Close()
+	})
+}
@@ -0,0 +1,80 @@
+package httpmod
+
+import (
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var funcNameSuffix = regexp.MustCompile(`(\.func\d+|\.\d+|-fm)$`)
+
+// RouteInfo description of the route created by Init
+type RouteInfo struct {
+	Module          string       `json:"module"`
+	Method          string       `json:"method"`
+	Path            string       `json:"path"`
+	Middleware      []string     `json:"middleware"`
+	RouteMiddleware []string     `json:"route_middleware"`
+	Handler         string       `json:"handler"`
+	Deprecation     *Deprecation `json:"deprecation,omitempty"`
+	Flags           []string     `json:"flags,omitempty"`
+	Meta            *Meta        `json:"meta,omitempty"`
+}
+
+// Table lists routes that Init creates for the modules, in registration order
+func Table(modules []func() Module) []RouteInfo {
+	table := []RouteInfo{}
+
+	walk(getModules(modules), func(s *scope, routePath string, route Route) {
+		info := RouteInfo{
+			Module:          s.path,
+			Method:          route.Method,
+			Path:            routePath,
+			Middleware:      funcNames(s.middleware),
+			RouteMiddleware: funcNames(route.Middleware),
+			Deprecation:     s.routeDeprecation(route),
+			Flags:           s.routeFlags(route),
+			Meta:            route.Meta,
+		}
+
+		switch {
+		case route.Socket != nil:
+			info.Handler = funcName(route.Socket)
+		case route.Stream != nil:
+			info.Handler = funcName(route.Stream)
+		case route.HandlerE != nil:
+			info.Handler = funcName(route.HandlerE)
+		case route.Handler != nil:
+			info.Handler = funcName(route.Handler)
+		}
+
+		table = append(table, info)
+	})
+
+	return table
+}
+
+func funcNames(handlers []gin.HandlerFunc) []string {
+	names := make([]string, 0, len(handlers))
+
+	for _, handler := range handlers {
+		names = append(names, funcName(handler))
+	}
+
+	return names
+}
+
+// funcName short function name, closures are reported by the function that created them
+func funcName(fn interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+
+	for funcNameSuffix.MatchString(name) {
+		name = funcNameSuffix.ReplaceAllString(name, "")
+	}
+
+	return name
+}
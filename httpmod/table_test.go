@@ -0,0 +1,74 @@
+package httpmod
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func tableTestAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {}
+}
+
+func tableTestLimit(c *gin.Context) {}
+
+func tableTestHandler(c *gin.Context) {}
+
+func tableTestHandlerE(c *gin.Context) error {
+	return nil
+}
+
+func TestTable(t *testing.T) {
+	assert := assert.New(t)
+	meta := &Meta{Summary: "list users"}
+
+	table := Table([]func() Module{
+		func() Module {
+			return Module{
+				Path:       "admin",
+				Middleware: []gin.HandlerFunc{tableTestAuth()},
+				Modules: []Module{
+					{
+						Path: "users",
+						Routes: []Route{
+							{
+								Path:       "/",
+								Method:     http.MethodGet,
+								Middleware: []gin.HandlerFunc{tableTestLimit},
+								Handler:    tableTestHandler,
+								Meta:       meta,
+							},
+							{
+								Path:     "/:id",
+								Method:   http.MethodDelete,
+								HandlerE: tableTestHandlerE,
+							},
+						},
+					},
+				},
+			}
+		},
+	})
+
+	assert.Equal([]RouteInfo{
+		{
+			Module:          "/admin/users",
+			Method:          http.MethodGet,
+			Path:            "/admin/users/",
+			Middleware:      []string{"httpmod.tableTestAuth"},
+			RouteMiddleware: []string{"httpmod.tableTestLimit"},
+			Handler:         "httpmod.tableTestHandler",
+			Meta:            meta,
+		},
+		{
+			Module:          "/admin/users",
+			Method:          http.MethodDelete,
+			Path:            "/admin/users/:id",
+			Middleware:      []string{"httpmod.tableTestAuth"},
+			RouteMiddleware: []string{},
+			Handler:         "httpmod.tableTestHandlerE",
+		},
+	}, table)
+}
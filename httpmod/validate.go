@@ -0,0 +1,156 @@
+package httpmod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrRouteConflict routes can not be registered together
+var ErrRouteConflict = errors.New("route conflict")
+
+// RouteConflict pair of routes that gin can not register together
+type RouteConflict struct {
+	Method         string
+	Path           string
+	Module         string
+	ConflictPath   string
+	ConflictModule string
+}
+
+// String human readable conflict description
+func (rc RouteConflict) String() string {
+	return fmt.Sprintf(
+		"%s %s (module %s) conflicts with %s %s (module %s)",
+		rc.Method,
+		rc.Path,
+		rc.Module,
+		rc.Method,
+		rc.ConflictPath,
+		rc.ConflictModule,
+	)
+}
+
+// ConflictError list of conflicting routes found by Init
+type ConflictError struct {
+	Conflicts []RouteConflict
+}
+
+// Error lists all conflicts
+func (e *ConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+
+	for _, conflict := range e.Conflicts {
+		conflicts = append(conflicts, conflict.String())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrRouteConflict, strings.Join(conflicts, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrRouteConflict)
+func (e *ConflictError) Unwrap() error {
+	return ErrRouteConflict
+}
+
+type tableEntry struct {
+	method string
+	path   string
+	module string
+}
+
+// validate checks module routes against each other and routes already registered in the router,
+// also makes sure routes have a method and (unless routes are mocked) a handler,
+// and flagged and streaming routes have a flag provider and streams
+func validate(router *gin.Engine, modules []Module, mock bool) error {
+	table := []tableEntry{}
+
+	for _, info := range router.Routes() {
+		table = append(table, tableEntry{info.Method, info.Path, "router"})
+	}
+
+	var err error
+
+	walk(modules, func(s *scope, routePath string, route Route) {
+		table = append(table, tableEntry{route.Method, routePath, s.path})
+
+		if err != nil {
+			return
+		}
+
+		switch {
+		case len(route.Method) <= 0:
+			err = fmt.Errorf("%w: %s has no method", ErrInvalidRoute, routePath)
+		case mock:
+		case route.Handler == nil && route.HandlerE == nil && route.Socket == nil && route.Stream == nil:
+			err = fmt.Errorf("%w: %s %s has no handler", ErrInvalidRoute, route.Method, routePath)
+		case len(s.routeFlags(route)) > 0 && s.flagProvider == nil:
+			err = fmt.Errorf("%w: %s %s", ErrNoFlagProvider, route.Method, routePath)
+		case (route.Socket != nil || route.Stream != nil) && s.streams == nil:
+			err = fmt.Errorf("%w: %s %s", ErrNoStreams, route.Method, routePath)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	conflicts := []RouteConflict{}
+
+	for i, entry := range table {
+		for _, existing := range table[:i] {
+			if entry.method == existing.method && isConflict(existing.path, entry.path) {
+				conflicts = append(conflicts, RouteConflict{
+					Method:         entry.method,
+					Path:           entry.path,
+					Module:         entry.module,
+					ConflictPath:   existing.path,
+					ConflictModule: existing.module,
+				})
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &ConflictError{conflicts}
+	}
+
+	return nil
+}
+
+// isConflict follows gin router rules: wildcards can't share a segment with
+// anything else, apart from a param next to a trailing slash
+func isConflict(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+
+		if isCatchAll(as[i]) || isCatchAll(bs[i]) {
+			return true
+		}
+
+		if isParam(as[i]) || isParam(bs[i]) {
+			return len(as[i]) > 0 && len(bs[i]) > 0
+		}
+
+		return false
+	}
+
+	return false
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, ":")
+}
+
+func isCatchAll(segment string) bool {
+	return strings.HasPrefix(segment, "*")
+}
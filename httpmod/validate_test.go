@@ -0,0 +1,161 @@
+package httpmod
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func validateTestModule(path string, routes ...string) func() Module {
+	return func() Module {
+		module := Module{Path: path}
+
+		for _, route := range routes {
+			module.Routes = append(module.Routes, Route{
+				Path:    route,
+				Method:  http.MethodGet,
+				Handler: func(c *gin.Context) {},
+			})
+		}
+
+		return module
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, test := range []struct {
+		A        string
+		B        string
+		Conflict bool
+	}{
+		{"/a", "/a", true},
+		{"/a", "/a/", false},
+		{"/a/b", "/a/c", false},
+		{"/u/:id", "/u/:name", true},
+		{"/u/:id", "/u/new", true},
+		{"/u/new", "/u/:id", true},
+		{"/u/:id", "/u/:id/x", false},
+		{"/u/:id/x", "/u/:name/y", true},
+		{"/u/:id", "/u/", false},
+		{"/u/:id", "/u", false},
+		{"/a/:b/c", "/a/:b/d", false},
+		{"/f/*p", "/f", false},
+		{"/f/", "/f/*p", true},
+		{"/f/*p", "/f/x", true},
+		{"/f/*p", "/f/*q", true},
+		{"/u/*p", "/u/:id", true},
+	} {
+		assert.Equal(test.Conflict, isConflict(test.A, test.B), "%s %s", test.A, test.B)
+	}
+}
+
+func TestInitConflicts(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	t.Run("duplicate route", func(t *testing.T) {
+		router := gin.New()
+		err := Init(router, []func() Module{
+			validateTestModule("users", "/list"),
+			validateTestModule("/users/", "list"),
+		})
+
+		conflict := new(ConflictError)
+		assert.True(errors.As(err, &conflict))
+		assert.True(errors.Is(err, ErrRouteConflict))
+		assert.Equal([]RouteConflict{
+			{
+				Method:         http.MethodGet,
+				Path:           "/users/list",
+				Module:         "/users/",
+				ConflictPath:   "/users/list",
+				ConflictModule: "/users",
+			},
+		}, conflict.Conflicts)
+		assert.Contains(err.Error(), "GET /users/list (module /users/) conflicts with GET /users/list (module /users)")
+		assert.Empty(router.Routes())
+	})
+
+	t.Run("wildcard conflict", func(t *testing.T) {
+		err := Init(gin.New(), []func() Module{
+			validateTestModule("users", "/:id", "/:name/posts", "/new"),
+		})
+
+		conflict := new(ConflictError)
+		assert.True(errors.As(err, &conflict))
+		assert.Len(conflict.Conflicts, 3)
+	})
+
+	t.Run("router conflict", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/status", func(c *gin.Context) {})
+
+		err := Init(router, []func() Module{
+			validateTestModule("", "/status"),
+		})
+
+		conflict := new(ConflictError)
+		assert.True(errors.As(err, &conflict))
+		assert.Equal("router", conflict.Conflicts[0].ConflictModule)
+	})
+
+	t.Run("invalid route", func(t *testing.T) {
+		router := gin.New()
+		err := Init(router, []func() Module{
+			validateTestModule("valid", "/hello"),
+			func() Module {
+				return Module{
+					Path: "test",
+					Routes: []Route{
+						{Path: "/hello", Handler: func(c *gin.Context) {}},
+					},
+				}
+			},
+		})
+
+		assert.True(errors.Is(err, ErrInvalidRoute))
+		assert.Empty(router.Routes())
+	})
+
+	t.Run("route rejected by router", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/status", func(c *gin.Context) {})
+
+		err := Init(router, []func() Module{
+			validateTestModule("test", "/u_x", "/u_:name"),
+		})
+
+		assert.True(errors.Is(err, ErrInvalidRoute))
+		assert.Len(router.Routes(), 1)
+	})
+
+	t.Run("route without handler", func(t *testing.T) {
+		router := gin.New()
+		err := Init(router, []func() Module{
+			validateTestModule("valid", "/hello"),
+			func() Module {
+				return Module{
+					Path: "test",
+					Routes: []Route{
+						{Path: "/hello", Method: http.MethodGet},
+					},
+				}
+			},
+		})
+
+		assert.True(errors.Is(err, ErrInvalidRoute))
+		assert.Empty(router.Routes())
+	})
+
+	t.Run("no conflicts", func(t *testing.T) {
+		assert.NoError(Init(gin.New(), []func() Module{
+			validateTestModule("users", "/:id", "/:id/posts", "/"),
+			validateTestModule("posts", "/:id"),
+		}))
+	})
+}
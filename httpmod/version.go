@@ -0,0 +1,106 @@
+package httpmod
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// DefaultVersionHeader header used to select version when VersionParams.Header is empty
+const DefaultVersionHeader = "Accept-Version"
+
+var (
+	acceptVersionParam  = regexp.MustCompile(`(?:^|;)\s*version=([\w.-]+)`)
+	acceptVersionVendor = regexp.MustCompile(`^application/vnd\.[\w.-]+?\.(v[\w-]+)\+`)
+)
+
+// VersionParams header based version selection parameters
+// Versions lists known module versions, Default is used when request doesn't specify a version
+type VersionParams struct {
+	Header   string
+	Versions []string
+	Default  string
+}
+
+// Versions routes requests without version prefix to the module version selected by:
+// * version header, e.g. "Accept-Version: v2"
+// * "Accept" media type parameter, e.g. "application/json; version=v2"
+// * "Accept" vendor media type, e.g. "application/vnd.company.v2+json"
+// * default version
+// requests that already have a known version prefix in the path are left untouched
+func Versions(handler http.Handler, p *VersionParams) http.Handler {
+	header := p.Header
+
+	if len(header) <= 0 {
+		header = DefaultVersionHeader
+	}
+
+	known := map[string]struct{}{}
+
+	for _, version := range p.Versions {
+		known[version] = struct{}{}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", header)
+		w.Header().Add("Vary", "Accept")
+
+		first := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0]
+
+		if _, ok := known[first]; ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		version := requestVersion(r, header)
+
+		if len(version) <= 0 {
+			version = p.Default
+		}
+
+		if _, ok := known[version]; ok {
+			r.URL.Path = joinPaths("/"+version, r.URL.Path)
+
+			if len(r.URL.RawPath) > 0 {
+				r.URL.RawPath = joinPaths("/"+version, r.URL.RawPath)
+			}
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func requestVersion(r *http.Request, header string) string {
+	if version := r.Header.Get(header); len(version) > 0 {
+		return version
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		accept = strings.TrimSpace(accept)
+
+		if match := acceptVersionParam.FindStringSubmatch(accept); match != nil {
+			return match[1]
+		}
+
+		if match := acceptVersionVendor.FindStringSubmatch(accept); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+// versions lists distinct module versions in the tree
+func versions(modules []Module) []string {
+	result := []string{}
+	seen := map[string]struct{}{}
+
+	for _, module := range flatten(modules) {
+		if _, ok := seen[module.Version]; !ok && len(module.Version) > 0 {
+			seen[module.Version] = struct{}{}
+			result = append(result, module.Version)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package httpmod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func versionTestModule(version string) func() Module {
+	return func() Module {
+		return Module{
+			Version: version,
+			Path:    "users",
+			Routes: []Route{
+				{
+					Path:   "/",
+					Method: http.MethodGet,
+					Handler: func(c *gin.Context) {
+						c.String(http.StatusOK, version)
+					},
+				},
+			},
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	modules := []func() Module{
+		versionTestModule("v1"),
+		versionTestModule("v2"),
+	}
+
+	assert.NoError(Init(router, modules))
+	assert.Equal([]string{"v1", "v2"}, versions(getModules(modules)))
+
+	srv := httptest.NewServer(Versions(router, &VersionParams{
+		Versions: versions(getModules(modules)),
+		Default:  "v1",
+	}))
+	defer srv.Close()
+
+	for _, test := range []struct {
+		Path    string
+		Headers map[string]string
+		Status  int
+		Version string
+	}{
+		{"/users/", map[string]string{}, http.StatusOK, "v1"},
+		{"/v2/users/", map[string]string{}, http.StatusOK, "v2"},
+		{"/v2/users/", map[string]string{DefaultVersionHeader: "v1"}, http.StatusOK, "v2"},
+		{"/users/", map[string]string{DefaultVersionHeader: "v2"}, http.StatusOK, "v2"},
+		{"/users/", map[string]string{"Accept": "application/json; version=v2"}, http.StatusOK, "v2"},
+		{"/users/", map[string]string{"Accept": "text/html, application/vnd.example.v2+json"}, http.StatusOK, "v2"},
+		{"/users/", map[string]string{DefaultVersionHeader: "v3"}, http.StatusNotFound, ""},
+	} {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", srv.URL, test.Path), nil)
+		assert.NoError(err)
+
+		for name, value := range test.Headers {
+			req.Header.Set(name, value)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(err)
+		defer res.Body.Close()
+		assert.Equal(test.Status, res.StatusCode)
+
+		if len(test.Version) > 0 {
+			data, err := ioutil.ReadAll(res.Body)
+			assert.NoError(err)
+			assert.Equal(test.Version, string(data))
+		}
+	}
+}
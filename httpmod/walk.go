@@ -0,0 +1,123 @@
+package httpmod
+
+import (
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scope position of the module in the tree
+// middleware, error handler, deprecation and flags are inherited from the parent modules
+type scope struct {
+	path         string
+	module       Module
+	middleware   []gin.HandlerFunc
+	errorHandler ErrorHandler
+	deprecation  *Deprecation
+	flags        []string
+	flagProvider FlagProvider
+	flagResponse gin.HandlerFunc
+	streams      *Streams
+}
+
+// routeDeprecation deprecation of the route, either own or inherited from modules
+func (s *scope) routeDeprecation(route Route) *Deprecation {
+	if route.Deprecation != nil {
+		return route.Deprecation
+	}
+
+	return s.deprecation
+}
+
+// routeFlags flags of the route and its parent modules
+func (s *scope) routeFlags(route Route) []string {
+	if len(route.Flag) > 0 {
+		return append(append([]string{}, s.flags...), route.Flag)
+	}
+
+	return s.flags
+}
+
+// walkFunc is called for every route in the module tree with the absolute route path
+type walkFunc func(s *scope, routePath string, route Route)
+
+func walk(modules []Module, fn walkFunc) {
+	for _, module := range modules {
+		walkModule(&scope{path: "/", errorHandler: DefaultErrorHandler, flagResponse: flagNotFound}, module, fn)
+	}
+}
+
+func walkModule(parent *scope, module Module, fn walkFunc) {
+	current := &scope{
+		path:         joinPaths(parent.path, module.Path),
+		module:       module,
+		middleware:   append(append([]gin.HandlerFunc{}, parent.middleware...), module.Middleware...),
+		errorHandler: parent.errorHandler,
+		deprecation:  parent.deprecation,
+		flags:        parent.flags,
+		flagProvider: parent.flagProvider,
+		flagResponse: parent.flagResponse,
+		streams:      parent.streams,
+	}
+
+	if len(module.Version) > 0 {
+		current.path = joinPaths(joinPaths(parent.path, module.Version), module.Path)
+	}
+
+	if module.ErrorHandler != nil {
+		current.errorHandler = module.ErrorHandler
+	}
+
+	if module.Deprecation != nil {
+		current.deprecation = module.Deprecation
+	}
+
+	if len(module.Flag) > 0 {
+		current.flags = append(append([]string{}, parent.flags...), module.Flag)
+	}
+
+	if module.Flags != nil {
+		current.flagProvider = module.Flags
+	}
+
+	if module.FlagResponse != nil {
+		current.flagResponse = module.FlagResponse
+	}
+
+	if module.Streams != nil {
+		current.streams = module.Streams
+	}
+
+	for _, route := range module.Routes {
+		fn(current, joinPaths(current.path, route.Path), route)
+	}
+
+	for _, child := range module.Modules {
+		walkModule(current, child, fn)
+	}
+}
+
+// joinPaths mirrors the way gin builds absolute paths for groups and routes
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+
+	finalPath := path.Join(absolutePath, relativePath)
+
+	if relativePath[len(relativePath)-1] == '/' && finalPath[len(finalPath)-1] != '/' {
+		return finalPath + "/"
+	}
+
+	return finalPath
+}
+
+func getModules(modules []func() Module) []Module {
+	result := make([]Module, 0, len(modules))
+
+	for _, getter := range modules {
+		result = append(result, getter())
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+package httpmod
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, test := range []struct {
+		Absolute string
+		Relative string
+		Result   string
+	}{
+		{"/", "", "/"},
+		{"/", "users", "/users"},
+		{"/users", "/:id", "/users/:id"},
+		{"/users", "/list/", "/users/list/"},
+		{"/users/", "", "/users/"},
+	} {
+		assert.Equal(test.Result, joinPaths(test.Absolute, test.Relative))
+	}
+}
+
+func TestWalk(t *testing.T) {
+	assert := assert.New(t)
+	paths := []string{}
+	middleware := []int{}
+	handler := func(c *gin.Context) {}
+
+	walk([]Module{
+		{
+			Path:       "admin",
+			Middleware: []gin.HandlerFunc{handler},
+			Routes:     []Route{{Path: "/"}},
+			Modules: []Module{
+				{
+					Path:       "users",
+					Middleware: []gin.HandlerFunc{handler},
+					Routes:     []Route{{Path: "/:id"}},
+				},
+			},
+		},
+	}, func(s *scope, routePath string, _ Route) {
+		paths = append(paths, s.path+" "+routePath)
+		middleware = append(middleware, len(s.middleware))
+	})
+
+	assert.Equal([]string{"/admin /admin/", "/admin/users /admin/users/:id"}, paths)
+	assert.Equal([]int{1, 2}, middleware)
+}
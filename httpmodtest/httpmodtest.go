@@ -0,0 +1,198 @@
+package httpmodtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httpmod"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+	"github.com/stretchr/testify/assert"
+)
+
+// Update rewrites golden files with actual responses, run tests with "-args -httpmodtest.update"
+var Update = flag.Bool("httpmodtest.update", false, "update httpmodtest golden files")
+
+type userKey struct{}
+
+// Case request fixture and expected response
+// Body is sent as is for string and []byte, other values are encoded as JSON
+// User is injected into the gin context under the "user" key before module middleware
+// JSON is compared semantically (string, []byte or any value encodable to JSON)
+// Golden is a path to the file with expected response body
+type Case struct {
+	Name            string
+	Method          string
+	Path            string
+	Headers         map[string]string
+	Body            interface{}
+	User            *httpmw.CognitoUser
+	Status          int
+	JSON            interface{}
+	ResponseHeaders map[string]string
+	Golden          string
+}
+
+// NewRouter creates gin router with the modules and user injection middleware
+func NewRouter(modules []func() httpmod.Module) (*gin.Engine, error) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(func(c *gin.Context) {
+		if user, ok := c.Request.Context().Value(userKey{}).(*httpmw.CognitoUser); ok {
+			c.Set("user", user)
+		}
+	})
+
+	return router, httpmod.Init(router, modules)
+}
+
+// Run mounts the modules and runs every case as a subtest
+func Run(t *testing.T, modules []func() httpmod.Module, cases []Case) {
+	router, err := NewRouter(modules)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range cases {
+		test := test
+		name := test.Name
+
+		if len(name) <= 0 {
+			name = test.Method + " " + test.Path
+		}
+
+		t.Run(name, func(t *testing.T) {
+			Do(t, router, test)
+		})
+	}
+}
+
+// Do executes single case against the handler
+func Do(t *testing.T, handler http.Handler, test Case) *httptest.ResponseRecorder {
+	assert := assert.New(t)
+	req, err := newRequest(test)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	if test.Status != 0 {
+		assert.Equal(test.Status, res.Code, "status")
+	}
+
+	for name, value := range test.ResponseHeaders {
+		assert.Equal(value, res.Header().Get(name), "header %s", name)
+	}
+
+	if test.JSON != nil {
+		expected, err := encode(test.JSON)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.JSONEq(string(expected), res.Body.String(), "body")
+	}
+
+	if len(test.Golden) > 0 {
+		golden(t, test.Golden, res.Body.Bytes())
+	}
+
+	return res
+}
+
+func newRequest(test Case) (*http.Request, error) {
+	var body io.Reader
+	contentType := ""
+
+	switch data := test.Body.(type) {
+	case nil:
+	case string:
+		body = bytes.NewBufferString(data)
+	case []byte:
+		body = bytes.NewBuffer(data)
+	default:
+		payload, err := json.Marshal(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewBuffer(payload)
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequest(test.Method, test.Path, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for name, value := range test.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if test.User != nil {
+		req = req.WithContext(context.WithValue(req.Context(), userKey{}, test.User))
+	}
+
+	return req, nil
+}
+
+func encode(value interface{}) ([]byte, error) {
+	switch data := value.(type) {
+	case string:
+		return []byte(data), nil
+	case []byte:
+		return data, nil
+	}
+
+	return json.Marshal(value)
+}
+
+// golden compares body with the golden file, JSON bodies are stored indented to keep diffs readable
+func golden(t *testing.T, name string, body []byte) {
+	indented := new(bytes.Buffer)
+
+	if json.Indent(indented, body, "", "  ") == nil {
+		indented.WriteString("\n")
+		body = indented.Bytes()
+	}
+
+	if *Update {
+		if err := ioutil.WriteFile(name, body, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	expected, err := ioutil.ReadFile(name)
+
+	if err != nil {
+		t.Fatalf("can't read golden file, run with -httpmodtest.update to create it: %v", err)
+	}
+
+	if json.Valid(expected) && json.Valid(body) {
+		assert.JSONEq(t, string(expected), string(body), "golden %s", name)
+		return
+	}
+
+	assert.Equal(t, string(expected), string(body), "golden %s", name)
+}
@@ -0,0 +1,100 @@
+package httpmodtest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/protsack-stephan/gin-toolkit/httpmod"
+	"github.com/protsack-stephan/gin-toolkit/httpmw"
+	"github.com/stretchr/testify/assert"
+)
+
+type httpmodtestUser struct {
+	Name string `json:"name"`
+}
+
+var httpmodtestModules = []func() httpmod.Module{
+	func() httpmod.Module {
+		return httpmod.Module{
+			Path: "users",
+			Routes: []httpmod.Route{
+				{
+					Path:   "/me",
+					Method: http.MethodGet,
+					Handler: func(c *gin.Context) {
+						user, ok := c.Get("user")
+
+						if !ok {
+							httperr.Unauthorized(c)
+							return
+						}
+
+						c.JSON(http.StatusOK, httpmodtestUser{user.(*httpmw.CognitoUser).GetUsername()})
+					},
+				},
+				{
+					Path:   "/",
+					Method: http.MethodPost,
+					Handler: func(c *gin.Context) {
+						user := new(httpmodtestUser)
+
+						if err := c.ShouldBindJSON(user); err != nil {
+							httperr.BadRequest(c, err.Error())
+							return
+						}
+
+						c.Header("Location", "/users/"+user.Name)
+						c.JSON(http.StatusCreated, user)
+					},
+				},
+			},
+		}
+	},
+}
+
+func TestRun(t *testing.T) {
+	user := new(httpmw.CognitoUser)
+	user.SetUsername("john")
+
+	Run(t, httpmodtestModules, []Case{
+		{
+			Name:   "anonymous",
+			Method: http.MethodGet,
+			Path:   "/users/me",
+			Status: http.StatusUnauthorized,
+			JSON:   httperr.NewError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)),
+		},
+		{
+			Name:   "injected user",
+			Method: http.MethodGet,
+			Path:   "/users/me",
+			User:   user,
+			Status: http.StatusOK,
+			JSON:   `{"name": "john"}`,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/users/",
+			Body:   httpmodtestUser{"jane"},
+			Status: http.StatusCreated,
+			ResponseHeaders: map[string]string{
+				"Location": "/users/jane",
+			},
+			Golden: "testdata/create_user.json",
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/users/",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    "{",
+			Status:  http.StatusBadRequest,
+		},
+	})
+}
+
+func TestNewRouter(t *testing.T) {
+	_, err := NewRouter([]func() httpmod.Module{})
+	assert.Equal(t, httpmod.ErrEmptyModules, err)
+}
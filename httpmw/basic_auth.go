@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
 )
 
 // BasicAuth middleware for basic authentication in format "user:pass,user2:pass2,user3:pass3"
@@ -21,5 +22,18 @@ func BasicAuth(storage string) gin.HandlerFunc {
 		return func(c *gin.Context) {}
 	}
 
-	return gin.BasicAuth(users)
+	pairs := processAccounts(users)
+
+	return func(c *gin.Context) {
+		user, found := pairs.searchCredential(c.Request.Header.Get("Authorization"))
+
+		if !found {
+			c.Header("WWW-Authenticate", "Basic realm=\"Authorization Required\"")
+			httperr.Unauthorized(c)
+			c.Abort()
+			return
+		}
+
+		c.Set(gin.AuthUserKey, user)
+	}
 }
@@ -2,11 +2,13 @@ package httpmw
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,6 +37,7 @@ func TestBasicAuth(t *testing.T) {
 	assert.NoError(t, err)
 	res.Body.Close()
 	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Equal(t, "Basic realm=\"Authorization Required\"", res.Header.Get("WWW-Authenticate"))
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	assert.NoError(t, err)
@@ -45,3 +48,26 @@ func TestBasicAuth(t *testing.T) {
 	res.Body.Close()
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 }
+
+func TestBasicAuthLocalized(t *testing.T) {
+	localizer := httperr.NewLocalizer("en")
+	localizer.Add("de", httperr.Bundle{"401": "Nicht autorisiert"})
+	httperr.SetLocalizer(localizer)
+	defer httperr.SetLocalizer(nil)
+
+	srv := httptest.NewServer(basicAutTestServer())
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+authTestURL, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept-Language", "de")
+
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Equal(t, `{"status":401,"message":"Nicht autorisiert"}`, string(body))
+}
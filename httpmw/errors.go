@@ -0,0 +1,20 @@
+package httpmw
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// Errors renders the last error added with c.Error when nothing else was written,
+// handlers can call c.Error(err) and return, see httperr.Render
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) <= 0 || c.Writer.Written() {
+			return
+		}
+
+		httperr.Render(c, c.Errors.Last().Err)
+	}
+}
@@ -0,0 +1,81 @@
+package httpmw
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+type errorsTestStatusErr struct{}
+
+func (e errorsTestStatusErr) Error() string {
+	return "conflict"
+}
+
+func (e errorsTestStatusErr) StatusCode() int {
+	return http.StatusConflict
+}
+
+func errorsTestServer() http.Handler {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Errors())
+
+	router.GET("/not-found", func(c *gin.Context) {
+		c.Error(fmt.Errorf("lookup: %w", httperr.Wrap(http.StatusNotFound, errors.New("user not found"))))
+	})
+
+	router.GET("/internal", func(c *gin.Context) {
+		c.Error(httperr.Wrap(http.StatusInternalServerError, errors.New("db password is wrong")))
+	})
+
+	router.GET("/plain", func(c *gin.Context) {
+		c.Error(errors.New("db password is wrong"))
+	})
+
+	router.GET("/status", func(c *gin.Context) {
+		c.Error(errorsTestStatusErr{})
+	})
+
+	router.GET("/written", func(c *gin.Context) {
+		c.Error(errors.New("ignored"))
+		c.String(http.StatusOK, "ok")
+	})
+
+	return router
+}
+
+func TestErrors(t *testing.T) {
+	assert := assert.New(t)
+	srv := httptest.NewServer(errorsTestServer())
+	defer srv.Close()
+
+	for _, test := range []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/not-found", http.StatusNotFound, `{"status":404,"message":"user not found"}`},
+		{"/internal", http.StatusInternalServerError, `{"status":500,"message":"Internal Server Error"}`},
+		{"/plain", http.StatusInternalServerError, `{"status":500,"message":"Internal Server Error"}`},
+		{"/status", http.StatusConflict, `{"status":409,"message":"conflict"}`},
+		{"/written", http.StatusOK, `ok`},
+	} {
+		res, err := http.Get(srv.URL + test.url)
+		assert.NoError(err)
+
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		assert.NoError(err)
+		assert.Equal(test.status, res.StatusCode, test.url)
+		assert.Equal(test.body, string(body), test.url)
+	}
+}
@@ -1,11 +1,13 @@
 package httpmw
 
 import (
+	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
-	"github.com/gin-gonic/gin"
-	"net/http"
 	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
 )
 
 type authPair struct {
@@ -18,7 +20,7 @@ type authPairs []authPair
 func (a authPairs) searchCredential(authValue string) (string, bool) {
 	if authValue != "" {
 		for _, pair := range a {
-			if pair.value == authValue {
+			if subtle.ConstantTimeCompare([]byte(pair.value), []byte(authValue)) == 1 {
 				return pair.user, true
 			}
 		}
@@ -57,7 +59,8 @@ func IPBasicAuth(ipRange string, authStorage string) gin.HandlerFunc {
 
 			if !found {
 				c.Header("WWW-Authenticate", "Basic realm=\"Authorization Required\"")
-				c.AbortWithStatus(http.StatusUnauthorized)
+				httperr.Unauthorized(c)
+				c.Abort()
 				return
 			}
 
@@ -207,15 +207,13 @@ func IpCognitoAuth(p *IpCognitoParams) gin.HandlerFunc {
 		data, err := p.Cache.Get(c, key).Bytes()
 
 		if err != nil && err != redis.Nil {
-			httperr.InternalServerError(c, err.Error())
-			c.Abort()
+			httperr.Abort(c, httperr.Wrap(http.StatusInternalServerError, err))
 			return
 		}
 
 		if err == nil {
 			if err := json.Unmarshal(data, user); err != nil {
-				httperr.InternalServerError(c, err.Error())
-				c.Abort()
+				httperr.Abort(c, httperr.Wrap(http.StatusInternalServerError, err))
 				return
 			}
 		}
@@ -233,8 +231,7 @@ func IpCognitoAuth(p *IpCognitoParams) gin.HandlerFunc {
 			data, err := json.Marshal(user)
 
 			if err != nil {
-				httperr.InternalServerError(c, err.Error())
-				c.Abort()
+				httperr.Abort(c, httperr.Wrap(http.StatusInternalServerError, err))
 				return
 			}
 
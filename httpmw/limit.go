@@ -3,6 +3,7 @@ package httpmw
 import (
 	"bytes"
 	"net"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +23,13 @@ func (v *visitor) allow() bool {
 	return v.limit.Allow()
 }
 
+func (v *visitor) retryAfter() time.Duration {
+	reservation := v.limit.Reserve()
+	defer reservation.Cancel()
+
+	return reservation.Delay()
+}
+
 func (v *visitor) seen() time.Duration {
 	return time.Since(v.reqTime)
 }
@@ -29,6 +37,8 @@ func (v *visitor) seen() time.Duration {
 type limiter struct {
 	visitors *sync.Map
 	limit    int
+	mut      sync.Mutex
+	cleaned  time.Time
 }
 
 func (l *limiter) visitor(ip string) *visitor {
@@ -40,6 +50,22 @@ func (l *limiter) visitor(ip string) *visitor {
 	return entity.(*visitor)
 }
 
+// cleanupEvery removes stale visitors at most once per interval, runs on requests so limiter needs no goroutine
+// and is garbage collected together with the middleware (e.g. after routes reload)
+func (l *limiter) cleanupEvery(interval time.Duration) {
+	l.mut.Lock()
+
+	if time.Since(l.cleaned) < interval {
+		l.mut.Unlock()
+		return
+	}
+
+	l.cleaned = time.Now()
+	l.mut.Unlock()
+
+	l.cleanup()
+}
+
 func (l *limiter) cleanup() {
 	l.visitors.Range(func(key interface{}, val interface{}) bool {
 		if val.(*visitor).seen() > time.Minute*1 {
@@ -67,18 +93,13 @@ func Limit(limit int, ipRanges ...string) gin.HandlerFunc {
 	}
 
 	limiter := &limiter{
-		&sync.Map{},
-		limit,
+		visitors: &sync.Map{},
+		limit:    limit,
+		cleaned:  time.Now(),
 	}
 
-	go func() {
-		for {
-			time.Sleep(time.Minute * 1)
-			limiter.cleanup()
-		}
-	}()
-
 	return func(c *gin.Context) {
+		limiter.cleanupEvery(time.Minute * 1)
 		ipAddr := c.ClientIP()
 		visitor := limiter.visitor(ipAddr)
 
@@ -88,19 +109,28 @@ func Limit(limit int, ipRanges ...string) gin.HandlerFunc {
 			for _, ips := range ranges {
 				if bytes.Compare(ip, ips[0]) >= 0 && bytes.Compare(ip, ips[1]) <= 0 {
 					if !visitor.allow() {
-						httperr.TooManyRequests(c)
-						c.Abort()
+						tooManyRequests(c, visitor.retryAfter())
 						return
 					}
 					break
 				}
 			}
 		} else if !visitor.allow() {
-			httperr.TooManyRequests(c)
-			c.Abort()
+			tooManyRequests(c, visitor.retryAfter())
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// tooManyRequests aborts with Retry-After header, header is omitted when retry time is unknown
+func tooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	opts := []httperr.Option{}
+
+	if retryAfter > 0 {
+		opts = append(opts, httperr.WithRetryAfter(retryAfter))
+	}
+
+	httperr.Abort(c, httperr.NewError(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests)), opts...)
+}
@@ -2,6 +2,7 @@ package httpmw
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -38,20 +39,18 @@ func LimitPerUser(cmdable redis.Cmdable, limit int, key string, expiration time.
 		allowed, err := limiter.Allow(c.Request.Context(), user.GetUsername())
 
 		if err != nil {
-			httperr.InternalServerError(c, err.Error())
-			c.Abort()
+			httperr.Abort(c, httperr.Wrap(http.StatusInternalServerError, err))
 			return
 		}
 
 		if !allowed {
-			httperr.TooManyRequests(c)
-			c.Abort()
+			retryAfter, _ := limiter.RetryAfter(c.Request.Context(), user.GetUsername())
+			tooManyRequests(c, retryAfter)
 			return
 		}
 
 		if err := limiter.Seen(c.Request.Context(), user.GetUsername()); err != nil {
-			httperr.InternalServerError(c, err.Error())
-			c.Abort()
+			httperr.Abort(c, httperr.Wrap(http.StatusInternalServerError, err))
 			return
 		}
 
@@ -64,6 +64,7 @@ func TestLimitPerUser(t *testing.T) {
 				assert.Equal(http.StatusOK, res.StatusCode)
 			} else {
 				assert.Equal(http.StatusTooManyRequests, res.StatusCode)
+				assert.Equal("1", res.Header.Get("Retry-After"))
 			}
 		}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -41,6 +42,7 @@ func TestLimit(t *testing.T) {
 				assert.Equal(http.StatusOK, res.StatusCode)
 			} else {
 				assert.Equal(http.StatusTooManyRequests, res.StatusCode)
+				assert.NotEmpty(res.Header.Get("Retry-After"))
 			}
 		}
 	})
@@ -61,6 +63,7 @@ func TestLimit(t *testing.T) {
 				assert.Equal(http.StatusOK, res.StatusCode)
 			} else {
 				assert.Equal(http.StatusTooManyRequests, res.StatusCode)
+				assert.NotEmpty(res.Header.Get("Retry-After"))
 			}
 		}
 
@@ -74,3 +77,14 @@ func TestLimit(t *testing.T) {
 		}
 	})
 }
+
+func TestLimitGoroutines(t *testing.T) {
+	assert := assert.New(t)
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		Limit(limitTestCount)
+	}
+
+	assert.Less(runtime.NumGoroutine(), before+100)
+}
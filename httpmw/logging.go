@@ -8,6 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// DeprecatedKey context key of the flag set by httpmod for requests to deprecated routes
+const DeprecatedKey = "deprecated"
+
 type logEntry struct {
 	ResponseTime string        `json:"response_time"`
 	Status       int           `json:"status"`
@@ -18,6 +21,9 @@ type logEntry struct {
 	Username     string        `json:"username,omitempty"`
 	UserGroups   []string      `json:"user_groups,omitempty"`
 	BodySize     int           `json:"body_size"`
+	Deprecated   bool          `json:"deprecated,omitempty"`
+	RequestID    string        `json:"request_id,omitempty"`
+	TraceID      string        `json:"trace_id,omitempty"`
 }
 
 // LogFormatter builds a logging entry in JSON format containing these fields:
@@ -33,6 +39,9 @@ type logEntry struct {
 // If a CognitoUser instance is found, the formatter will also include the following fields:
 // - Username
 // - User associated group(s)
+//
+// Requests to deprecated routes (marked by httpmod under DeprecatedKey) are flagged with "deprecated" field.
+// Request and trace IDs set by RequestID middleware are included as "request_id" and "trace_id" fields.
 func LogFormatter(p gin.LogFormatterParams) string {
 	entry := &logEntry{
 		ResponseTime: p.TimeStamp.Format(time.RFC3339),
@@ -55,6 +64,18 @@ func LogFormatter(p gin.LogFormatterParams) string {
 		entry.UserGroups = user.GetGroups()
 	}
 
+	if deprecated, ok := p.Keys[DeprecatedKey].(bool); ok {
+		entry.Deprecated = deprecated
+	}
+
+	if requestID, ok := p.Keys["request_id"].(string); ok {
+		entry.RequestID = requestID
+	}
+
+	if traceID, ok := p.Keys["trace_id"].(string); ok {
+		entry.TraceID = traceID
+	}
+
 	b, _ := json.Marshal(entry)
 	return fmt.Sprintln(string(b))
 }
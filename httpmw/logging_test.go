@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -87,6 +88,65 @@ func TestLogFormatter(t *testing.T) {
 
 			assert.Equal(testUser.Username, entry.Username)
 			assert.ElementsMatch(testUser.Groups, entry.UserGroups)
+			assert.False(entry.Deprecated)
+		})
+
+		t.Run("test deprecated route", func(_ *testing.T) {
+			e := gin.New()
+			out := new(bytes.Buffer)
+
+			e.Use(gin.LoggerWithConfig(gin.LoggerConfig{
+				Formatter: LogFormatter,
+				Output:    out,
+			}))
+
+			e.GET(apiPath, func(c *gin.Context) {
+				c.Set(DeprecatedKey, true)
+				c.Status(http.StatusTeapot)
+			})
+
+			req, err := http.NewRequest(http.MethodGet, apiPath, nil)
+			assert.NoError(err)
+
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+
+			entry := new(logEntry)
+			assert.NoError(json.Unmarshal(out.Bytes(), entry))
+			assert.True(entry.Deprecated)
+		})
+
+		t.Run("test request and trace id", func(_ *testing.T) {
+			e := gin.New()
+			out := new(bytes.Buffer)
+
+			e.Use(gin.LoggerWithConfig(gin.LoggerConfig{
+				Formatter: LogFormatter,
+				Output:    out,
+			}))
+			e.Use(RequestID())
+
+			e.GET(apiPath, func(c *gin.Context) {
+				httperr.NotFound(c)
+			})
+
+			req, err := http.NewRequest(http.MethodGet, apiPath, nil)
+			assert.NoError(err)
+			req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+
+			body := new(httperr.Error)
+			assert.NoError(json.Unmarshal(w.Body.Bytes(), body))
+
+			entry := new(logEntry)
+			assert.NoError(json.Unmarshal(out.Bytes(), entry))
+			assert.NotEmpty(entry.RequestID)
+			assert.Equal(entry.RequestID, body.RequestID)
+			assert.Equal(entry.RequestID, w.Header().Get(RequestIDHeader))
+			assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", entry.TraceID)
+			assert.Equal(entry.TraceID, body.TraceID)
 		})
 	})
 }
@@ -1,6 +1,8 @@
 package httpmw
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/protsack-stephan/gin-toolkit/httperr"
 )
@@ -14,8 +16,7 @@ func RBAC(fn RBACAuthorizeFunc) gin.HandlerFunc {
 		ok, err := fn(c)
 
 		if err != nil {
-			httperr.InternalServerError(c, err.Error())
-			c.Abort()
+			httperr.Abort(c, httperr.Wrap(http.StatusInternalServerError, err))
 			return
 		}
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,6 +51,12 @@ func TestRBACMiddleware(t *testing.T) {
 		})
 
 		t.Run("test access denied with error", func(t *testing.T) {
+			reports := []*httperr.Report{}
+			httperr.SetReporter(httperr.ReporterFunc(func(report *httperr.Report) {
+				reports = append(reports, report)
+			}))
+			defer httperr.SetReporter(nil)
+
 			router := gin.New()
 			router.Use(RBAC(func(c *gin.Context) (bool, error) {
 				return true, errors.New("test error")
@@ -63,6 +70,9 @@ func TestRBACMiddleware(t *testing.T) {
 			router.ServeHTTP(w, req)
 
 			assert.Equal(http.StatusInternalServerError, w.Code)
+			assert.NotContains(w.Body.String(), "test error")
+			assert.Len(reports, 1)
+			assert.Equal("test error", reports[0].Cause)
 		})
 	})
 }
@@ -0,0 +1,121 @@
+package httpmw
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// PanicReport recovered panic with request details
+type PanicReport struct {
+	Time      time.Time   `json:"time"`
+	Value     interface{} `json:"value"`
+	Stack     string      `json:"stack"`
+	Method    string      `json:"method"`
+	Path      string      `json:"path"`
+	RequestID string      `json:"request_id,omitempty"`
+	TraceID   string      `json:"trace_id,omitempty"`
+	Username  string      `json:"username,omitempty"`
+}
+
+// PanicReporter receives recovered panics
+type PanicReporter interface {
+	ReportPanic(report *PanicReport)
+}
+
+// PanicReporterFunc function adapter for PanicReporter
+type PanicReporterFunc func(report *PanicReport)
+
+// ReportPanic calls the function
+func (fn PanicReporterFunc) ReportPanic(report *PanicReport) {
+	fn(report)
+}
+
+// RecoveryParams recovery middleware parameters
+// Reporter defaults to printing reports to gin.DefaultErrorWriter
+// Stack includes panic value and stack in the response detail, only when gin runs in debug mode
+type RecoveryParams struct {
+	Reporter PanicReporter
+	Stack    bool
+}
+
+// Recovery recovers from panics, reports them and responds with internal server error
+// http.ErrAbortHandler is panicked again, broken connections are recorded in c.Errors without report and response
+func Recovery(p *RecoveryParams) gin.HandlerFunc {
+	if p == nil {
+		p = new(RecoveryParams)
+	}
+
+	reporter := p.Reporter
+
+	if reporter == nil {
+		reporter = PanicReporterFunc(func(report *PanicReport) {
+			fmt.Fprintf(gin.DefaultErrorWriter, "[Recovery] %s panic recovered: %s %s: %v\n%s\n", report.Time.Format(time.RFC3339), report.Method, report.Path, report.Value, report.Stack)
+		})
+	}
+
+	return func(c *gin.Context) {
+		defer func() {
+			val := recover()
+
+			if val == nil {
+				return
+			}
+
+			if val == http.ErrAbortHandler {
+				panic(val)
+			}
+
+			if err, ok := val.(error); ok && brokenConnection(err) {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+
+			report := &PanicReport{
+				Time:      time.Now(),
+				Value:     val,
+				Stack:     string(debug.Stack()),
+				Method:    c.Request.Method,
+				Path:      c.Request.URL.Path,
+				RequestID: httperr.RequestID(c),
+				TraceID:   httperr.TraceID(c),
+			}
+
+			if model, ok := c.Get("user"); ok {
+				if user, ok := model.(*CognitoUser); ok {
+					report.Username = user.GetUsername()
+				}
+			}
+
+			reporter.ReportPanic(report)
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			err := httperr.Wrap(http.StatusInternalServerError, fmt.Errorf("panic: %v", val))
+
+			if p.Stack && gin.IsDebugging() {
+				err.Detail = fmt.Sprintf("%v\n%s", val, report.Stack)
+			}
+
+			httperr.Respond(c, err)
+			c.Abort()
+		}()
+
+		c.Next()
+	}
+}
+
+// brokenConnection client went away while response was written
+func brokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
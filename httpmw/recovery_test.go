@@ -0,0 +1,136 @@
+package httpmw
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+const recoveryTestURL = "/panic"
+const recoveryTestUsername = "john"
+const recoveryTestRequestID = "abc-123"
+
+func recoveryTestServer(p *RecoveryParams) http.Handler {
+	router := gin.New()
+	router.Use(Recovery(p))
+	router.Use(func(c *gin.Context) {
+		user := new(CognitoUser)
+		user.SetUsername(recoveryTestUsername)
+		c.Set("user", user)
+	})
+
+	router.GET(recoveryTestURL, func(c *gin.Context) {
+		panic("something went wrong")
+	})
+
+	router.GET("/abort", func(c *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	router.GET("/broken-pipe", func(c *gin.Context) {
+		panic(&net.OpError{Op: "write", Net: "tcp", Err: &os.SyscallError{Syscall: "write", Err: syscall.EPIPE}})
+	})
+
+	router.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	return router
+}
+
+func TestRecovery(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	mut := sync.Mutex{}
+	reports := []*PanicReport{}
+	handler := recoveryTestServer(&RecoveryParams{
+		Reporter: PanicReporterFunc(func(report *PanicReport) {
+			mut.Lock()
+			defer mut.Unlock()
+			reports = append(reports, report)
+		}),
+		Stack: true,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, recoveryTestURL, nil)
+	req.Header.Set(RequestIDHeader, recoveryTestRequestID)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusInternalServerError, res.Code)
+
+	herr := new(httperr.Error)
+	assert.NoError(json.Unmarshal(res.Body.Bytes(), herr))
+	assert.Equal(http.StatusText(http.StatusInternalServerError), herr.Message)
+	assert.Empty(herr.Detail)
+
+	assert.Len(reports, 1)
+	assert.Equal("something went wrong", reports[0].Value)
+	assert.Equal(http.MethodGet, reports[0].Method)
+	assert.Equal(recoveryTestURL, reports[0].Path)
+	assert.Equal(recoveryTestRequestID, reports[0].RequestID)
+	assert.Equal(recoveryTestUsername, reports[0].Username)
+	assert.Contains(reports[0].Stack, "recovery_test.go")
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(http.StatusNoContent, res.Code)
+	assert.Len(reports, 1)
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/broken-pipe", nil))
+	assert.False(res.Flushed)
+	assert.Empty(res.Body.String())
+	assert.Len(reports, 1)
+
+	assert.PanicsWithValue(http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+	})
+	assert.Len(reports, 1)
+}
+
+func TestRecoveryDebug(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.DebugMode)
+	defer gin.SetMode(gin.TestMode)
+
+	handler := recoveryTestServer(&RecoveryParams{
+		Reporter: PanicReporterFunc(func(report *PanicReport) {}),
+		Stack:    true,
+	})
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, recoveryTestURL, nil))
+	assert.Equal(http.StatusInternalServerError, res.Code)
+
+	herr := new(httperr.Error)
+	assert.NoError(json.Unmarshal(res.Body.Bytes(), herr))
+	assert.True(strings.HasPrefix(herr.Detail, "something went wrong\n"))
+	assert.Contains(herr.Detail, "recovery_test.go")
+}
+
+func TestRecoveryDefaultReporter(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+
+	writer := gin.DefaultErrorWriter
+	buf := new(strings.Builder)
+	gin.DefaultErrorWriter = buf
+	defer func() { gin.DefaultErrorWriter = writer }()
+
+	res := httptest.NewRecorder()
+	recoveryTestServer(nil).ServeHTTP(res, httptest.NewRequest(http.MethodGet, recoveryTestURL, nil))
+	assert.Equal(http.StatusInternalServerError, res.Code)
+	assert.Contains(buf.String(), "panic recovered: GET /panic: something went wrong")
+}
@@ -67,3 +67,18 @@ func (rl *RedisLimiter) Seen(ctx context.Context, identifier string) error {
 
 	return rl.cmdable.Incr(ctx, key).Err()
 }
+
+// RetryAfter time left until identifier counter expires, 0 if counter has no expiration
+func (rl *RedisLimiter) RetryAfter(ctx context.Context, identifier string) (time.Duration, error) {
+	if rl.expire == 0 {
+		return 0, nil
+	}
+
+	ttl, err := rl.cmdable.PTTL(ctx, rl.getKey(identifier)).Result()
+
+	if err != nil || ttl < 0 {
+		return 0, err
+	}
+
+	return ttl, nil
+}
@@ -68,4 +68,25 @@ func TestLimiter(t *testing.T) {
 		assert.NoError(err)
 		assert.True(ok)
 	})
+
+	t.Run("test retry after", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		assert.NoError(err)
+		defer mr.Close()
+
+		cmd := redis.NewClient(&redis.Options{
+			Addr: mr.Addr(),
+		})
+		lmr := NewRedisLimiter(cmd, limiterTestEntity, 2, exp)
+
+		retryAfter, err := lmr.RetryAfter(ctx, limiterTestIdentifier)
+		assert.NoError(err)
+		assert.Zero(retryAfter)
+
+		assert.NoError(lmr.Seen(ctx, limiterTestIdentifier))
+
+		retryAfter, err = lmr.RetryAfter(ctx, limiterTestIdentifier)
+		assert.NoError(err)
+		assert.Equal(exp, retryAfter)
+	})
 }
@@ -0,0 +1,46 @@
+package httpmw
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+)
+
+// RequestIDHeader header carrying request ID
+const RequestIDHeader = httperr.RequestIDHeader
+
+// TraceParentHeader W3C trace context header
+const TraceParentHeader = "traceparent"
+
+var traceParentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+// RequestID middleware reuses valid X-Request-ID request header or generates new ID, trace ID is taken from
+// traceparent header, IDs are stored under "request_id" and "trace_id" keys and echoed in response headers
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+
+		if !httperr.ValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Set("request_id", id)
+		c.Header(RequestIDHeader, id)
+
+		if match := traceParentPattern.FindStringSubmatch(c.GetHeader(TraceParentHeader)); match != nil && match[1] != "00000000000000000000000000000000" {
+			c.Set("trace_id", match[1])
+			c.Header(httperr.TraceIDHeader, match[1])
+		}
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,68 @@
+package httpmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/protsack-stephan/gin-toolkit/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+const requestIDTestURL = "/request-id"
+
+func requestIDTestServer() http.Handler {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET(requestIDTestURL, func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("request_id")+" "+c.GetString("trace_id"))
+	})
+
+	return router
+}
+
+func TestRequestID(t *testing.T) {
+	assert := assert.New(t)
+	handler := requestIDTestServer()
+
+	for _, test := range []struct {
+		name        string
+		requestID   string
+		traceParent string
+		generated   bool
+		traceID     string
+	}{
+		{"generated", "", "", true, ""},
+		{"reused", "abc-123", "", false, ""},
+		{"invalid id", "abc 123<script>", "", true, ""},
+		{"trace parent", "abc-123", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"invalid trace parent", "abc-123", "00-4bf92f3577b34da6a3ce929d0e0e4736-01", false, ""},
+		{"zero trace id", "abc-123", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, requestIDTestURL, nil)
+		req.Header.Set(RequestIDHeader, test.requestID)
+		req.Header.Set(TraceParentHeader, test.traceParent)
+
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		id := res.Header().Get(RequestIDHeader)
+
+		if test.generated {
+			assert.Len(id, 32, test.name)
+		} else {
+			assert.Equal(test.requestID, id, test.name)
+		}
+
+		assert.Equal(test.traceID, res.Header().Get(httperr.TraceIDHeader), test.name)
+		assert.Equal(id+" "+test.traceID, res.Body.String(), test.name)
+	}
+
+	first, second := httptest.NewRecorder(), httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, requestIDTestURL, nil))
+	handler.ServeHTTP(second, httptest.NewRequest(http.MethodGet, requestIDTestURL, nil))
+	assert.NotEqual(first.Header().Get(RequestIDHeader), second.Header().Get(RequestIDHeader))
+}